@@ -0,0 +1,106 @@
+package creature
+
+import (
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+// Anything that occupies a cell on a level and takes turns
+type Actor interface {
+	Location() grid.Point
+	SetLocation(x int, y int)
+	Glyph() rune
+	Colour() grid.Colour
+	Describe() string
+	IsAlive() bool
+	Stats() Stats
+	Speed() int
+	Ready() bool
+	Spend(cost int)
+	body() *Body
+}
+
+// State shared by the player and monsters
+type Body struct {
+	X         int
+	Y         int
+	HitPoints int
+	Energy    int
+	Equipment item.Equipment
+	Effects   []Effect
+	*Species  `json:"Species"`
+}
+
+func newBody(species *Species) Body {
+	return Body{0, 0, species.HitPoints, 0, item.Equipment{}, nil, species}
+}
+
+func (b *Body) body() *Body {
+	return b
+}
+
+func (b *Body) SetLocation(x int, y int) {
+	b.X = x
+	b.Y = y
+}
+
+func (b *Body) Location() grid.Point {
+	return grid.Point{X: b.X, Y: b.Y}
+}
+
+// Return rune of a living actor, or CORPSE_RUNE for the dead
+func (b *Body) Glyph() rune {
+	if !b.IsAlive() {
+		return CORPSE_RUNE
+	}
+	return b.Rune
+}
+
+// Return colour the actor is drawn with
+func (b *Body) Colour() grid.Colour {
+	if !b.IsAlive() {
+		return CORPSE_COLOUR
+	}
+	return b.Species.Colour
+}
+
+func (b *Body) IsAlive() bool {
+	return b.HitPoints > 0
+}
+
+// Return maximum hit points
+func (b *Body) MaxHitPoints() int {
+	return b.Species.HitPoints
+}
+
+// Return false if moving to direction would squeeze between two walls where the world's squeeze rule forbids it
+func (b *Body) canPass(w *World, direction grid.Direction) bool {
+	return b.canSqueeze(w) || !w.Grid.IsSqueeze(b.X, b.Y, direction, grid.CellIsTraversable.Not())
+}
+
+// Return true if the world's squeeze rule lets the actor pass diagonally between two walls
+func (b *Body) canSqueeze(w *World) bool {
+	return w.Squeezing.Allows(b.Large)
+}
+
+// Return a pathfinder for routes the actor can walk along, opening closed doors on the way
+func (b *Body) pathfinder(w *World) *grid.Pathfinder {
+	p := grid.NewPathfinder(grid.AllDirections, cellIsWalkable, walkingCost)
+	p.Squeeze = b.canSqueeze(w)
+	return p
+}
+
+// Move one cell to direction using the energy of a move, if the cell is traversable and free
+func (b *Body) step(w *World, direction grid.Direction) error {
+	tx := b.X + direction.Dx
+	ty := b.Y + direction.Dy
+
+	if w.IsFree(tx, ty) {
+		b.X = tx
+		b.Y = ty
+		b.Spend(MOVE_COST)
+		return nil
+	} else {
+		return CANNOT_MOVE_THERE
+	}
+}
@@ -0,0 +1,66 @@
+package creature
+
+import "github.com/mahe-go/grogue/grid"
+
+// Function type for function deciding what a monster does on its turn.
+// Returns true if the behaviour applied and the monster acted, false to let another behaviour decide.
+type Behaviour func(m *Monster, w *World) bool
+
+// Return a behaviour trying self first and other if self doesn't apply
+func (self Behaviour) Or(other Behaviour) Behaviour {
+	return func(m *Monster, w *World) bool {
+		return self(m, w) || other(m, w)
+	}
+}
+
+// Behaviours by name, as referred to by Species.Behaviour
+var Behaviours = map[string]Behaviour{
+	"wanderer": Wander,
+	"hunter":   ChaseOnSight.Or(Wander),
+	"coward":   FleeBelow(50).Or(ChaseOnSight).Or(Wander),
+}
+
+// Move to a random free neighbouring cell, if there is one
+var Wander Behaviour = func(m *Monster, w *World) bool {
+	directions := grid.AllDirections
+	start := w.Rng.Intn(len(directions))
+	for i := range directions {
+		if m.MoveOne(w, directions[(start+i)%len(directions)]) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Move towards the player when the player is in sight
+var ChaseOnSight Behaviour = func(m *Monster, w *World) bool {
+	if !m.seesPlayer(w) {
+		return false
+	}
+	path, err := m.pathfinder(w).AStar(w.Grid, m.X, m.Y, w.Player.X, w.Player.Y)
+	if err == nil && len(path) > 0 {
+		m.MoveOne(w, m.Location().DirectionTo(path[0]))
+	}
+	return true
+}
+
+// Run away from the player when in sight and hit points drop below percent of maximum
+func FleeBelow(percent int) Behaviour {
+	return func(m *Monster, w *World) bool {
+		if m.HitPoints*100 >= m.MaxHitPoints()*percent || !m.seesPlayer(w) {
+			return false
+		}
+		goals := m.pathfinder(w).GoalMap(w.Grid, w.Player.Location())
+		if direction, ok := goals.Flee(1.2).Downhill(m.X, m.Y); ok {
+			m.MoveOne(w, direction)
+		}
+		return true
+	}
+}
+
+func (m *Monster) seesPlayer(w *World) bool {
+	if w.Player == nil || !w.Player.IsAlive() {
+		return false
+	}
+	return w.Grid.FieldOfView(m.X, m.Y, m.Stats().Sight, nil).IsVisible(w.Player.X, w.Player.Y)
+}
@@ -0,0 +1,68 @@
+package creature
+
+import (
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+// Energy cost of a melee attack
+const ATTACK_COST = 100
+
+// Rune and colour of a dead monster left on the grid
+const CORPSE_RUNE = '%'
+const CORPSE_COLOUR = grid.RED
+
+type EventKind int
+
+const (
+	HIT EventKind = iota
+	MISS
+	DEATH
+	PICK_UP
+	DROP
+	EQUIP
+	UNEQUIP
+	EFFECT_STARTED
+	POISON_DAMAGE
+	EFFECT_EXPIRED
+	OPEN_DOOR
+	CLOSE_DOOR
+	UNLOCK_DOOR
+)
+
+// Something that happened during a turn, reported to World.Report.
+// Actor is nil for things no one did, like dying of poison.
+type Event struct {
+	Kind   EventKind
+	Actor  Actor
+	Target Actor
+	Amount int
+	Item   *item.Item
+	Effect EffectKind
+}
+
+// Resolve melee attack of attacker against defender, using the energy of an attack.
+// Damage is rolled from the world's random source: up to the attacker's attack value,
+// reduced by up to the defender's defense value. A hit by a venomous attacker poisons the defender.
+func Attack(w *World, attacker Actor, defender Actor) {
+	a := attacker.body()
+	d := defender.body()
+	a.Spend(ATTACK_COST)
+
+	damage := 1 + w.Rng.Intn(a.Stats().Attack+1) - w.Rng.Intn(d.Stats().Defense+1)
+	if damage <= 0 {
+		w.report(Event{Kind: MISS, Actor: attacker, Target: defender})
+		return
+	}
+	d.HitPoints -= damage
+	w.report(Event{Kind: HIT, Actor: attacker, Target: defender, Amount: damage})
+	if !d.IsAlive() {
+		d.HitPoints = 0
+		w.report(Event{Kind: DEATH, Actor: attacker, Target: defender})
+		return
+	}
+	if a.Venom > 0 {
+		d.AddEffect(Effect{Kind: POISONED, Duration: POISON_DURATION, Magnitude: a.Venom})
+		w.report(Event{Kind: EFFECT_STARTED, Actor: attacker, Target: defender, Effect: POISONED})
+	}
+}
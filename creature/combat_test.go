@@ -0,0 +1,40 @@
+package creature
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+func TestVenomousHitPoisons(t *testing.T) {
+	var events []Event
+	w := &World{Rng: rand.New(rand.NewSource(1)), Report: func(e Event) { events = append(events, e) }}
+	spider := NewMonster(NewSpecies("spider", 1, 's', grid.WHITE, 5, 10, 0, 6, "hunter", false, 2))
+	target := NewPlayer("Target", NewSpecies("troll", 1, 'T', grid.GREEN, 1000, 0, 0, 6, "", true, 0))
+
+	Attack(w, spider, target)
+	Attack(w, spider, target)
+
+	if len(target.Effects) != 1 || target.Effects[0].Kind != POISONED {
+		t.Fatalf("effects %v, want poison", target.Effects)
+	}
+	if target.Effects[0].Magnitude != 4 || target.Effects[0].Duration != POISON_DURATION {
+		t.Errorf("two bites gave %+v, want magnitude 4 for %d turns", target.Effects[0], POISON_DURATION)
+	}
+	if events[1].Kind != EFFECT_STARTED || events[1].Effect != POISONED {
+		t.Errorf("second event %+v, want poisoning", events[1])
+	}
+}
+
+func TestHarmlessHitDoesNotPoison(t *testing.T) {
+	w := &World{Rng: rand.New(rand.NewSource(1))}
+	kobold := NewMonster(NewSpecies("kobold", 1, 'k', grid.GREEN, 8, 10, 0, 7, "hunter", false, 0))
+	target := NewPlayer("Target", NewSpecies("troll", 1, 'T', grid.GREEN, 1000, 0, 0, 6, "", true, 0))
+
+	Attack(w, kobold, target)
+
+	if len(target.Effects) != 0 {
+		t.Errorf("effects %v, want none", target.Effects)
+	}
+}
@@ -0,0 +1,59 @@
+package creature
+
+import (
+	"errors"
+
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+var NO_DOOR = errors.New("No door there")
+var DOOR_IS_LOCKED = errors.New("Door is locked")
+var DOORWAY_BLOCKED = errors.New("Doorway is blocked")
+
+// Open the closed door next to the player in direction
+func (p *Player) OpenDoor(w *World, direction grid.Direction) error {
+	x, y := p.X+direction.Dx, p.Y+direction.Dy
+	if w.Grid.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_LOCKED), x, y) {
+		return DOOR_IS_LOCKED
+	}
+	return changeDoor(w, p, x, y, grid.DOOR_CLOSED, grid.DOOR_OPEN, DOOR_COST, OPEN_DOOR)
+}
+
+// Close the open door next to the player in direction. Doorways with something in them can't be closed.
+func (p *Player) CloseDoor(w *World, floor *item.Layer, direction grid.Direction) error {
+	x, y := p.X+direction.Dx, p.Y+direction.Dy
+	if w.Grid.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_OPEN), x, y) && (w.ActorAt(x, y) != nil || floor.Top(x, y) != nil) {
+		return DOORWAY_BLOCKED
+	}
+	return changeDoor(w, p, x, y, grid.DOOR_OPEN, grid.DOOR_CLOSED, DOOR_COST, CLOSE_DOOR)
+}
+
+// Unlock the locked door next to the player in direction, leaving it closed. Picking a lock takes a while.
+func (p *Player) UnlockDoor(w *World, direction grid.Direction) error {
+	return changeDoor(w, p, p.X+direction.Dx, p.Y+direction.Dy, grid.DOOR_LOCKED, grid.DOOR_CLOSED, UNLOCK_COST, UNLOCK_DOOR)
+}
+
+// Let actor turn the door at (x,y) from one type to another, or return NO_DOOR if there is no door of type from
+func changeDoor(w *World, actor Actor, x int, y int, from grid.CellType, to grid.CellType, cost int, kind EventKind) error {
+	if !w.Grid.TestCellAtXY(grid.GridCellIsOfType(from), x, y) {
+		return NO_DOOR
+	}
+	w.Grid.ApplyToCellAtXY(grid.GridCellTypeConverter(to), x, y)
+	actor.body().Spend(cost)
+	w.report(Event{Kind: kind, Actor: actor})
+	return nil
+}
+
+// Creatures walk through closed doors by opening them, which takes as long as a step
+const CLOSED_DOOR_PATH_COST = 2
+
+// Cells creatures can find paths through
+var cellIsWalkable = grid.CellIsTraversable.Or(grid.GridCellIsOfType(grid.DOOR_CLOSED))
+
+var walkingCost grid.CellCost = func(cell grid.GridCell) int {
+	if cell.Type == grid.DOOR_CLOSED {
+		return CLOSED_DOOR_PATH_COST
+	}
+	return 1
+}
@@ -0,0 +1,60 @@
+package creature
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+const closedRoom = `#####
+#...#
+#...+..
+#####`
+
+func TestMonstersFindPathsThroughClosedDoors(t *testing.T) {
+	g, err := grid.ParseGrid(closedRoom, grid.Legend{'.': grid.ROOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &World{Grid: g, Rng: rand.New(rand.NewSource(1))}
+	kobold := NewMonster(&KOBOLD)
+	kobold.SetLocation(3, 2)
+	w.Monsters = []*Monster{kobold}
+
+	path, err := kobold.pathfinder(w).AStar(g, 3, 2, 6, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path[0] != (grid.Point{X: 4, Y: 2}) {
+		t.Fatalf("path %v does not lead through the door", path)
+	}
+
+	kobold.Energy = ACTION_THRESHOLD
+	if err := kobold.MoveOne(w, grid.East); err != nil {
+		t.Fatal(err)
+	}
+	if !g.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_OPEN), 4, 2) || kobold.X != 3 {
+		t.Errorf("kobold at (%d,%d) did not open the door", kobold.X, kobold.Y)
+	}
+	if kobold.Energy != ACTION_THRESHOLD-DOOR_COST {
+		t.Errorf("opening the door left %d energy", kobold.Energy)
+	}
+}
+
+func TestMonstersDoNotPassLockedDoors(t *testing.T) {
+	g, err := grid.ParseGrid(closedRoom, grid.Legend{'.': grid.ROOM, '+': grid.DOOR_LOCKED})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &World{Grid: g, Rng: rand.New(rand.NewSource(1))}
+	kobold := NewMonster(&KOBOLD)
+	kobold.SetLocation(3, 2)
+
+	if _, err := kobold.pathfinder(w).AStar(g, 3, 2, 6, 2); err != grid.NO_PATH {
+		t.Errorf("got %v, want no path past a locked door", err)
+	}
+	if err := kobold.MoveOne(w, grid.East); err != CANNOT_MOVE_THERE {
+		t.Errorf("got %v, want %v", err, CANNOT_MOVE_THERE)
+	}
+}
@@ -0,0 +1,148 @@
+package creature
+
+import (
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/util"
+)
+
+type EffectKind int
+
+// Turns the poison of a venomous bite lasts
+const POISON_DURATION = 5
+
+const (
+	POISONED EffectKind = iota
+	SLOWED
+	HASTED
+	CONFUSED
+	BLINDED
+	REGENERATING
+)
+
+// How a new effect combines with an effect of the same kind already in place
+type StackRule int
+
+const (
+	// Keep one effect with the longer of the durations
+	REFRESH StackRule = iota
+	// Keep one effect, adding the durations
+	EXTEND
+	// Keep one effect with the longer duration, adding the magnitudes
+	INTENSIFY
+)
+
+type effectKindInfo struct {
+	name     string
+	stacking StackRule
+	cancels  []EffectKind
+}
+
+var effectKinds = map[EffectKind]effectKindInfo{
+	POISONED:     {"poisoned", INTENSIFY, nil},
+	SLOWED:       {"slowed", REFRESH, []EffectKind{HASTED}},
+	HASTED:       {"hasted", REFRESH, []EffectKind{SLOWED}},
+	CONFUSED:     {"confused", REFRESH, nil},
+	BLINDED:      {"blinded", REFRESH, nil},
+	REGENERATING: {"regenerating", EXTEND, nil},
+}
+
+func (k EffectKind) String() string {
+	return effectKinds[k].name
+}
+
+// Status effect lasting Duration turns. Magnitude is the damage or healing per turn
+// of poison and regeneration, and has no meaning for other kinds.
+type Effect struct {
+	Kind      EffectKind
+	Duration  int
+	Magnitude int
+}
+
+// Add effect to creature according to the stacking rule of its kind.
+// Haste and slowness cancel each other instead of stacking.
+func (b *Body) AddEffect(e Effect) {
+	for _, cancelled := range effectKinds[e.Kind].cancels {
+		if b.HasEffect(cancelled) {
+			b.removeEffect(cancelled)
+			return
+		}
+	}
+	for i := range b.Effects {
+		existing := &b.Effects[i]
+		if existing.Kind != e.Kind {
+			continue
+		}
+		switch effectKinds[e.Kind].stacking {
+		case REFRESH:
+			existing.Duration = util.Max(existing.Duration, e.Duration)
+			existing.Magnitude = util.Max(existing.Magnitude, e.Magnitude)
+		case EXTEND:
+			existing.Duration += e.Duration
+			existing.Magnitude = util.Max(existing.Magnitude, e.Magnitude)
+		case INTENSIFY:
+			existing.Duration = util.Max(existing.Duration, e.Duration)
+			existing.Magnitude += e.Magnitude
+		}
+		return
+	}
+	b.Effects = append(b.Effects, e)
+}
+
+// Return true if creature is under an effect of kind
+func (b *Body) HasEffect(kind EffectKind) bool {
+	for _, e := range b.Effects {
+		if e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Body) removeEffect(kind EffectKind) {
+	kept := b.Effects[:0]
+	for _, e := range b.Effects {
+		if e.Kind != kind {
+			kept = append(kept, e)
+		}
+	}
+	b.Effects = kept
+}
+
+// Apply per-turn effects to actor and count down their durations, removing expired effects
+func tickEffects(w *World, a Actor) {
+	b := a.body()
+	kept := b.Effects[:0]
+	for _, e := range b.Effects {
+		switch e.Kind {
+		case POISONED:
+			if b.IsAlive() {
+				b.HitPoints -= e.Magnitude
+				w.report(Event{Kind: POISON_DAMAGE, Target: a, Amount: e.Magnitude, Effect: POISONED})
+				if !b.IsAlive() {
+					b.HitPoints = 0
+					w.report(Event{Kind: DEATH, Target: a, Effect: POISONED})
+				}
+			}
+		case REGENERATING:
+			if b.IsAlive() {
+				b.HitPoints = util.Min(b.HitPoints+e.Magnitude, b.MaxHitPoints())
+			}
+		}
+		e.Duration--
+		if e.Duration > 0 {
+			kept = append(kept, e)
+		} else {
+			w.report(Event{Kind: EFFECT_EXPIRED, Target: a, Effect: e.Kind})
+		}
+	}
+	b.Effects = kept
+}
+
+// Return the direction a creature actually moves to when trying to move to direction.
+// Confused creatures stumble in a random direction.
+func (b *Body) intendedDirection(w *World, direction grid.Direction) grid.Direction {
+	if b.HasEffect(CONFUSED) {
+		return grid.AllDirections[w.Rng.Intn(len(grid.AllDirections))]
+	}
+	return direction
+}
@@ -0,0 +1,75 @@
+package creature
+
+import (
+	"math/rand"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+type Monster struct {
+	Body
+}
+
+func NewMonster(species *Species) *Monster {
+	return &Monster{newBody(species)}
+}
+
+func (m *Monster) Describe() string {
+	return m.Species.Name
+}
+
+// Move one cell to direction, or attack the player standing there. Monsters don't attack each other.
+// Walking into a closed door opens it. Confused monsters stumble to a random direction instead.
+func (m *Monster) MoveOne(w *World, direction grid.Direction) error {
+	direction = m.intendedDirection(w, direction)
+	if !m.canPass(w, direction) {
+		return CANNOT_MOVE_THERE
+	}
+	x, y := m.X+direction.Dx, m.Y+direction.Dy
+	if w.Player != nil && w.ActorAt(x, y) == Actor(w.Player) {
+		Attack(w, m, w.Player)
+		return nil
+	}
+	if w.Grid.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_CLOSED), x, y) {
+		return changeDoor(w, m, x, y, grid.DOOR_CLOSED, grid.DOOR_OPEN, DOOR_COST, OPEN_DOOR)
+	}
+	return m.step(w, direction)
+}
+
+// Act according to the behaviour of the monster's species.
+// Monsters without a known behaviour, or whose behaviour didn't do anything, wait.
+func (m *Monster) TakeTurn(w *World) {
+	if !m.IsAlive() {
+		return
+	}
+	energy := m.Energy
+	if behaviour, ok := Behaviours[m.Behaviour]; ok {
+		behaviour(m, w)
+	}
+	if m.Energy == energy {
+		m.Spend(WAIT_COST)
+	}
+}
+
+// Place count monsters of species picked randomly from bestiary to free cells matching where
+func SpawnMonsters(w *World, bestiary []Species, count int, where grid.LocationPredicate, rng *rand.Rand) []*Monster {
+	var candidates []grid.Point
+	w.Grid.ApplyEverywhereMatching(func(g *grid.Grid, x int, y int) error {
+		candidates = append(candidates, grid.Point{X: x, Y: y})
+		return nil
+	}, where.And(w.CellIsFree()))
+
+	var spawned []*Monster
+	for i := 0; i < count && len(candidates) > 0 && len(bestiary) > 0; i++ {
+		pick := rng.Intn(len(candidates))
+		location := candidates[pick]
+		candidates = append(candidates[:pick], candidates[pick+1:]...)
+
+		species := bestiary[rng.Intn(len(bestiary))]
+		m := NewMonster(&species)
+		m.SetLocation(location.X, location.Y)
+		spawned = append(spawned, m)
+		w.Monsters = append(w.Monsters, m)
+	}
+	return spawned
+}
@@ -4,47 +4,113 @@ import (
 	"errors"
 
 	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
 )
 
 var CANNOT_MOVE_THERE = errors.New("Not accessible")
 
+// Number of items a player can carry
+const INVENTORY_CAPACITY = 26
+
 type Player struct {
-	X    int
-	Y    int
-	Name string
-	*Species
+	Body
+	Name      string
+	Inventory *item.Inventory
 }
 
+// Return a new player with an empty inventory, ready to act
 func NewPlayer(name string, species *Species) *Player {
-	return &Player{0, 0, name, species}
+	p := &Player{newBody(species), name, item.NewInventory(INVENTORY_CAPACITY)}
+	p.Energy = ACTION_THRESHOLD
+	return p
 }
 
-func (p *Player) SetLocation(x int, y int) {
-	p.X = x
-	p.Y = y
+func (p *Player) Describe() string {
+	return p.Name
 }
 
-func (p *Player) MoveOne(g *grid.Grid, direction grid.Direction) error {
-	tx := p.X + direction.Dx
-	ty := p.Y + direction.Dy
-
-	if g.TestCellAtXY(func(cell grid.GridCell) bool {
-		return cell.Type.Traversable
-	}, tx, ty) {
-		p.X = tx
-		p.Y = ty
-		return nil
-	} else {
+// Move one cell to direction, or attack the monster standing there. Walking into a closed door opens it.
+// Failed moves cost no energy.
+func (p *Player) MoveOne(w *World, direction grid.Direction) error {
+	if !p.canPass(w, direction) {
 		return CANNOT_MOVE_THERE
 	}
+	if target := w.ActorAt(p.X+direction.Dx, p.Y+direction.Dy); target != nil {
+		Attack(w, p, target)
+		return nil
+	}
+	if w.Grid.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_CLOSED), p.X+direction.Dx, p.Y+direction.Dy) {
+		return p.OpenDoor(w, direction)
+	}
+	if w.Grid.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_LOCKED), p.X+direction.Dx, p.Y+direction.Dy) {
+		return DOOR_IS_LOCKED
+	}
+	return p.step(w, direction)
+}
+
+// Move or attack to direction. Confused players stumble to a random direction instead.
+func (p *Player) Move(w *World, direction grid.Direction) error {
+	return p.MoveOne(w, p.intendedDirection(w, direction))
+}
+
+// Pick up the topmost item from the floor under the player
+func (p *Player) PickUp(w *World, floor *item.Layer) error {
+	top := floor.Top(p.X, p.Y)
+	if top == nil {
+		return item.NOTHING_HERE
+	}
+	if err := p.Inventory.Add(top); err != nil {
+		return err
+	}
+	floor.Take(p.X, p.Y)
+	p.Spend(PICKUP_COST)
+	w.report(Event{Kind: PICK_UP, Actor: p, Item: top})
+	return nil
+}
+
+// Drop item at index of inventory to the floor under the player
+func (p *Player) Drop(w *World, floor *item.Layer, index int) error {
+	dropped, err := p.Inventory.Remove(index)
+	if err != nil {
+		return err
+	}
+	floor.Put(p.X, p.Y, dropped)
+	p.Spend(DROP_COST)
+	w.report(Event{Kind: DROP, Actor: p, Item: dropped})
+	return nil
 }
 
-func (p *Player) Move(g *grid.Grid, direction grid.Direction) error {
-	var err error
-	for i := 0; i < p.Movement && err == nil; i++ {
-		err = p.MoveOne(g, direction)
+// Wield or wear item at index of inventory. Any item previously in the same slot goes back to the inventory.
+func (p *Player) Equip(w *World, index int) error {
+	if index < 0 || index >= len(p.Inventory.Items) {
+		return item.NO_SUCH_ITEM
+	}
+	if !p.Inventory.Items[index].IsEquippable() {
+		return item.NOT_EQUIPPABLE
+	}
+	equipped, _ := p.Inventory.Remove(index)
+	previous, _ := p.Equipment.Equip(equipped)
+	if previous != nil {
+		p.Inventory.Add(previous)
+	}
+	p.Spend(EQUIP_COST)
+	w.report(Event{Kind: EQUIP, Actor: p, Item: equipped})
+	return nil
+}
+
+// Take off item in slot and put it to the inventory
+func (p *Player) Unequip(w *World, slot item.Slot) error {
+	if len(p.Inventory.Items) >= p.Inventory.Capacity {
+		return item.INVENTORY_FULL
+	}
+	removed, err := p.Equipment.Unequip(slot)
+	if err != nil {
+		return err
 	}
-	return err
+	p.Inventory.Add(removed)
+	p.Spend(EQUIP_COST)
+	w.report(Event{Kind: UNEQUIP, Actor: p, Item: removed})
+	return nil
 }
 
 func PlacePlayerToGridAtMatching(player *Player, g *grid.Grid, predicate grid.CellPredicate) {
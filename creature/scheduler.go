@@ -0,0 +1,89 @@
+package creature
+
+// Energy an actor needs to take an action
+const ACTION_THRESHOLD = 100
+
+// Energy an actor gains on every tick for each point of Species.Movement
+const ENERGY_PER_TICK = 10
+
+// Ticks in one turn, the time an actor of speed 1 needs to gather energy for an action
+const TICKS_PER_TURN = ACTION_THRESHOLD / ENERGY_PER_TICK
+
+// Energy costs of actions
+const (
+	MOVE_COST   = 100
+	WAIT_COST   = 100
+	STAIRS_COST = 150
+	PICKUP_COST = 50
+	DROP_COST   = 50
+	EQUIP_COST  = 100
+	DOOR_COST   = 100
+	UNLOCK_COST = 300
+)
+
+// Energy based turn scheduler. On every tick each actor gains energy according to its speed,
+// and acts when it has gathered enough. A speed 2 actor thus acts twice as often as a speed 1 actor.
+type Scheduler struct {
+	Tick int
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{0}
+}
+
+// Return number of full turns elapsed
+func (s *Scheduler) Turn() int {
+	return s.Tick / TICKS_PER_TURN
+}
+
+// Advance time, letting monsters act whenever they have energy, until the player has energy to act again
+// or is dead.
+func (s *Scheduler) RunUntilPlayerReady(w *World) {
+	for w.Player.IsAlive() && !w.Player.Ready() {
+		s.Tick++
+		if s.Tick%TICKS_PER_TURN == 0 {
+			s.tickEffects(w)
+		}
+		w.Player.gainEnergy()
+		for _, m := range w.Monsters {
+			if !m.IsAlive() {
+				continue
+			}
+			m.gainEnergy()
+			for m.IsAlive() && m.Ready() {
+				m.TakeTurn(w)
+			}
+		}
+	}
+}
+
+// Count down status effects of all living actors once a turn
+func (s *Scheduler) tickEffects(w *World) {
+	if w.Player.IsAlive() {
+		tickEffects(w, w.Player)
+	}
+	for _, m := range w.Monsters {
+		if m.IsAlive() {
+			tickEffects(w, m)
+		}
+	}
+}
+
+func (b *Body) gainEnergy() {
+	b.Energy += b.Speed()
+}
+
+// Return true if actor has enough energy to act
+func (b *Body) Ready() bool {
+	return b.Energy >= ACTION_THRESHOLD
+}
+
+// Use energy for an action costing cost
+func (b *Body) Spend(cost int) {
+	b.Energy -= cost
+}
+
+// Return energy actor gains per tick, as given by its stats
+func (b *Body) Speed() int {
+	return b.Stats().Speed
+}
@@ -1,10 +1,33 @@
 package creature
 
+import "github.com/mahe-go/grogue/grid"
+
 type Species struct {
-	Movement int
-	Rune     rune
+	Name      string
+	Movement  int
+	Rune      rune
+	Colour    grid.Colour
+	HitPoints int
+	Attack    int
+	Defense   int
+	Sight     int
+	Behaviour string
+	// Large creatures may be barred from squeezing diagonally between two walls, see grid.SqueezeRule
+	Large bool
+	// Poison damage per turn a hit inflicts, see POISON_DURATION. Zero for creatures without venom.
+	Venom int
 }
 
-func NewSpecies(movement int, r rune) *Species {
-	return &Species{movement, r}
+func NewSpecies(name string, movement int, r rune, colour grid.Colour, hitPoints int, attack int, defense int, sight int, behaviour string, large bool, venom int) *Species {
+	return &Species{name, movement, r, colour, hitPoints, attack, defense, sight, behaviour, large, venom}
 }
+
+var HUMAN = Species{Name: "human", Movement: 1, Rune: '@', Colour: grid.YELLOW, HitPoints: 20, Attack: 5, Defense: 2, Sight: 8}
+var RAT = Species{Name: "rat", Movement: 1, Rune: 'r', Colour: grid.MAGENTA, HitPoints: 4, Attack: 2, Defense: 0, Sight: 5, Behaviour: "coward"}
+var KOBOLD = Species{Name: "kobold", Movement: 1, Rune: 'k', Colour: grid.GREEN, HitPoints: 8, Attack: 3, Defense: 1, Sight: 7, Behaviour: "hunter"}
+var BAT = Species{Name: "bat", Movement: 2, Rune: 'b', Colour: grid.MAGENTA, HitPoints: 3, Attack: 1, Defense: 0, Sight: 4, Behaviour: "wanderer"}
+var ORC = Species{Name: "orc", Movement: 1, Rune: 'o', Colour: grid.RED, HitPoints: 14, Attack: 5, Defense: 2, Sight: 7, Behaviour: "hunter", Large: true}
+var SPIDER = Species{Name: "spider", Movement: 1, Rune: 's', Colour: grid.WHITE, HitPoints: 5, Attack: 1, Defense: 0, Sight: 6, Behaviour: "hunter", Venom: 2}
+
+// Species monsters are spawned from
+var Bestiary = []Species{RAT, KOBOLD, BAT, ORC, SPIDER}
@@ -0,0 +1,54 @@
+package creature
+
+import "github.com/mahe-go/grogue/item"
+
+// Effective values of the stats of a creature. Speed is the energy gained on every tick of the scheduler.
+type Stats struct {
+	Attack  int
+	Defense int
+	Speed   int
+	Sight   int
+}
+
+// Compute the effective stats of a creature: the base stats of its species
+// with the bonuses of its equipment on top, modified by its status effects.
+// Speed bonuses are in energy per tick, so they adjust the speed of the species rather than multiply it.
+// Speed is always at least 1, other stats at least 0.
+func ComputeStats(species *Species, equipment item.Equipment, effects []Effect) Stats {
+	bonus := equipment.Bonus()
+	stats := Stats{
+		Attack:  species.Attack + bonus.Attack,
+		Defense: species.Defense + bonus.Defense,
+		Speed:   species.Movement*ENERGY_PER_TICK + bonus.Speed,
+		Sight:   species.Sight + bonus.Sight,
+	}
+	for _, e := range effects {
+		switch e.Kind {
+		case HASTED:
+			stats.Speed *= 2
+		case SLOWED:
+			stats.Speed /= 2
+		case BLINDED:
+			stats.Sight = 0
+		}
+	}
+	if stats.Speed < 1 {
+		stats.Speed = 1
+	}
+	stats.Attack = atLeastZero(stats.Attack)
+	stats.Defense = atLeastZero(stats.Defense)
+	stats.Sight = atLeastZero(stats.Sight)
+	return stats
+}
+
+func atLeastZero(x int) int {
+	if x < 0 {
+		return 0
+	}
+	return x
+}
+
+// Return effective stats of the creature
+func (b *Body) Stats() Stats {
+	return ComputeStats(b.Species, b.Equipment, b.Effects)
+}
@@ -0,0 +1,50 @@
+package creature
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/item"
+)
+
+func catalogItem(t *testing.T, name string) *item.Item {
+	for _, template := range item.Catalog {
+		if template.Name == name {
+			i := template
+			return &i
+		}
+	}
+	t.Fatalf("no %s in catalog", name)
+	return nil
+}
+
+// Ticks an actor of speed needs to gather energy for one move
+func ticksPerMove(speed int) int {
+	return (MOVE_COST + speed - 1) / speed
+}
+
+func TestMaceSlowsByAFractionOfATurn(t *testing.T) {
+	unarmed := ComputeStats(&HUMAN, item.Equipment{}, nil)
+	armed := ComputeStats(&HUMAN, item.Equipment{item.WEAPON_SLOT: catalogItem(t, "mace")}, nil)
+	if armed.Speed >= unarmed.Speed {
+		t.Errorf("mace should slow, speed %d unarmed %d", armed.Speed, unarmed.Speed)
+	}
+	if extra := ticksPerMove(armed.Speed) - ticksPerMove(unarmed.Speed); extra >= TICKS_PER_TURN/2 {
+		t.Errorf("moving with a mace takes %d extra ticks, a turn is %d", extra, TICKS_PER_TURN)
+	}
+}
+
+func TestRingOfHasteIsWeakerThanHaste(t *testing.T) {
+	ring := ComputeStats(&HUMAN, item.Equipment{item.RING_SLOT: catalogItem(t, "ring of haste")}, nil)
+	hasted := ComputeStats(&HUMAN, item.Equipment{}, []Effect{{Kind: HASTED, Duration: 5}})
+	unarmed := ComputeStats(&HUMAN, item.Equipment{}, nil)
+	if ring.Speed <= unarmed.Speed || ring.Speed >= hasted.Speed {
+		t.Errorf("ring of haste speed %d, want between %d and %d", ring.Speed, unarmed.Speed, hasted.Speed)
+	}
+}
+
+func TestSpeedIsAtLeastOne(t *testing.T) {
+	slow := item.Item{Kind: item.WEAPON, Name: "anvil", Amount: 1, Slot: item.WEAPON_SLOT, Bonus: item.Bonus{Speed: -100}}
+	if stats := ComputeStats(&HUMAN, item.Equipment{item.WEAPON_SLOT: &slow}, nil); stats.Speed != 1 {
+		t.Errorf("speed %d, want 1", stats.Speed)
+	}
+}
@@ -0,0 +1,48 @@
+package creature
+
+import (
+	"math/rand"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+// Everything an actor can see and touch while taking its turn
+type World struct {
+	Grid      *grid.Grid
+	Player    *Player
+	Monsters  []*Monster
+	Rng       *rand.Rand
+	Report    func(e Event)
+	Squeezing grid.SqueezeRule
+}
+
+func (w *World) report(e Event) {
+	if w.Report != nil {
+		w.Report(e)
+	}
+}
+
+// Return the living actor at (x,y), or nil if there is none
+func (w *World) ActorAt(x int, y int) Actor {
+	if w.Player != nil && w.Player.IsAlive() && w.Player.X == x && w.Player.Y == y {
+		return w.Player
+	}
+	for _, m := range w.Monsters {
+		if m.IsAlive() && m.X == x && m.Y == y {
+			return m
+		}
+	}
+	return nil
+}
+
+// Return true if (x,y) is traversable and not occupied by a living actor
+func (w *World) IsFree(x int, y int) bool {
+	return w.Grid.TestCellAtXY(grid.CellIsTraversable, x, y) && w.ActorAt(x, y) == nil
+}
+
+// LocationPredicate matching free cells, for placing actors
+func (w *World) CellIsFree() grid.LocationPredicate {
+	return func(g *grid.Grid, x int, y int) bool {
+		return w.IsFree(x, y)
+	}
+}
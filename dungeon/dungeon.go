@@ -0,0 +1,160 @@
+package dungeon
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+	"github.com/mahe-go/grogue/util"
+)
+
+var NOT_ON_STAIRCASE = errors.New("No staircase here")
+var NO_LEVEL_ABOVE = errors.New("No level above")
+var MALFORMED_DUNGEON = errors.New("Malformed dungeon")
+
+// One level of the dungeon and everything on it. Levels are kept when the player leaves them.
+type Level struct {
+	Depth    int
+	Grid     *grid.Grid
+	Metadata *grid.Metadata
+	Monsters []*creature.Monster
+	Items    *item.Layer
+	Explored *grid.VisibilityMask
+	Memory   *Memory
+}
+
+// Monsters are spawned anywhere except on staircases, so that arriving on a level is safe
+var spawnLocation grid.LocationPredicate = func(g *grid.Grid, x int, y int) bool {
+	return g.TestCellAtXY(grid.GridCellIsOfType(grid.STAIRCASE_UP).Or(grid.GridCellIsOfType(grid.STAIRCASE_DOWN)).Not(), x, y)
+}
+
+// Return number of monsters spawned on a new level at depth
+func monstersAtDepth(depth int) int {
+	return 3 + depth
+}
+
+// Return number of items spawned on a new level at depth
+func itemsAtDepth(depth int) int {
+	return 2 + depth/2
+}
+
+// Prime spreading the seeds of consecutive levels apart, see levelSeed.
+// Changing it changes every level of every shared seed.
+const LEVEL_SEED_STRIDE = 7919
+
+// Return the seed level at depth of a dungeon is generated from: the dungeon seed plus depth strides.
+// Levels thus don't depend on each other, and the dungeon seed alone reproduces all of them.
+func levelSeed(seed int64, depth int) int64 {
+	return seed + int64(depth)*LEVEL_SEED_STRIDE
+}
+
+// Stack of levels indexed by depth, starting from 1. Levels are generated when first visited.
+// The staircase down of each level leads to the staircase up of the level below it.
+type Dungeon struct {
+	levels []*Level
+	Depth  int
+	Width  int
+	Height int
+	Seed   int64
+	Config grid.GeneratorConfiguration
+}
+
+// Return a dungeon of width x height levels with the first level generated
+func NewDungeon(width int, height int, seed int64, config grid.GeneratorConfiguration) (*Dungeon, error) {
+	d := &Dungeon{nil, 1, width, height, seed, config}
+	_, err := d.Level(1)
+	return d, err
+}
+
+// Return level at depth, generating it and any levels above it if needed.
+// Each level is generated from its own random source seeded with levelSeed,
+// so the same seed always produces the same levels regardless of the order they are visited in.
+func (d *Dungeon) Level(depth int) (*Level, error) {
+	if depth < 1 {
+		return nil, NO_LEVEL_ABOVE
+	}
+	for len(d.levels) < depth {
+		next := len(d.levels) + 1
+		rng := util.NewRand(levelSeed(d.Seed, next))
+		g, metadata, err := d.Config.Generate(next, d.Width, d.Height, rng)
+		if err != nil {
+			return nil, err
+		}
+		w := &creature.World{Grid: g, Rng: rng}
+		creature.SpawnMonsters(w, creature.Bestiary, monstersAtDepth(next), spawnLocation, rng)
+		items := item.NewLayer()
+		item.SpawnItems(items, g, item.Catalog, itemsAtDepth(next), spawnLocation.And(grid.CellIsTraversable.AtXY()), rng)
+		d.levels = append(d.levels, &Level{next, g, metadata, w.Monsters, items, grid.NewVisibilityMask(g.Width, g.Height), NewMemory(g.Width, g.Height)})
+	}
+	return d.levels[depth-1], nil
+}
+
+// Return the level at current depth
+func (d *Dungeon) Current() *Level {
+	return d.levels[d.Depth-1]
+}
+
+// Take the staircase down at (x,y) on current level. Returns the location of the linked staircase up on the level below.
+func (d *Dungeon) Descend(x int, y int) (grid.Point, error) {
+	if !d.Current().Grid.TestCellAtXY(grid.GridCellIsOfType(grid.STAIRCASE_DOWN), x, y) {
+		return grid.Point{}, NOT_ON_STAIRCASE
+	}
+	level, err := d.Level(d.Depth + 1)
+	if err != nil {
+		return grid.Point{}, err
+	}
+	d.Depth = level.Depth
+	return level.Metadata.StairsUp, nil
+}
+
+// Take the staircase up at (x,y) on current level. Returns the location of the linked staircase down on the level above.
+func (d *Dungeon) Ascend(x int, y int) (grid.Point, error) {
+	if !d.Current().Grid.TestCellAtXY(grid.GridCellIsOfType(grid.STAIRCASE_UP), x, y) {
+		return grid.Point{}, NOT_ON_STAIRCASE
+	}
+	level, err := d.Level(d.Depth - 1)
+	if err != nil {
+		return grid.Point{}, err
+	}
+	d.Depth = level.Depth
+	return level.Metadata.StairsDown, nil
+}
+
+// Serialized form of a dungeon
+type encodedDungeon struct {
+	Levels []*Level
+	Depth  int
+	Width  int
+	Height int
+	Seed   int64
+	Config grid.GeneratorConfiguration
+}
+
+func (d *Dungeon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encodedDungeon{d.levels, d.Depth, d.Width, d.Height, d.Seed, d.Config})
+}
+
+func (d *Dungeon) UnmarshalJSON(data []byte) error {
+	var encoded encodedDungeon
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if encoded.Depth < 1 || encoded.Depth > len(encoded.Levels) {
+		return MALFORMED_DUNGEON
+	}
+	for _, level := range encoded.Levels {
+		if level == nil || level.Grid == nil || level.Metadata == nil || level.Items == nil || level.Explored == nil ||
+			level.Memory == nil || level.Memory.Terrain == nil || level.Memory.Items == nil {
+			return MALFORMED_DUNGEON
+		}
+		for _, m := range level.Monsters {
+			if m == nil || m.Species == nil {
+				return MALFORMED_DUNGEON
+			}
+		}
+	}
+	*d = Dungeon{encoded.Levels, encoded.Depth, encoded.Width, encoded.Height, encoded.Seed, encoded.Config}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dungeon
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/util"
+)
+
+func TestLevelSeedIsStable(t *testing.T) {
+	// Shared seeds must keep producing the same dungeons, so the scheme must not change
+	if seed := levelSeed(1234, 3); seed != 1234+3*7919 {
+		t.Errorf("level seed %d", seed)
+	}
+}
+
+func TestLevelIsGeneratedFromItsOwnSeed(t *testing.T) {
+	d, err := NewDungeon(60, 20, 42, grid.DefaultGeneratorConfiguration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	level, err := d.Level(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alone, _, err := d.Config.Generate(3, 60, 20, util.NewRand(levelSeed(42, 3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.Grid.String() != alone.String() {
+		t.Errorf("level 3 of the dungeon:\n%s\ndiffers from level 3 generated alone:\n%s", level.Grid, alone)
+	}
+}
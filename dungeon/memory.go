@@ -0,0 +1,33 @@
+package dungeon
+
+import (
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+// What the player saw on the explored cells of a level when last seeing them. Cells out of sight are drawn
+// from memory, so that doors opened and items taken out of sight only change when the player sees them again.
+type Memory struct {
+	Terrain *grid.Grid
+	Items   *item.Layer
+}
+
+func NewMemory(width int, height int) *Memory {
+	return &Memory{grid.NewSolidGridOfType(width, height, grid.SOLID_ROCK), item.NewLayer()}
+}
+
+// Remember the terrain and items of level on the visible cells, and mark them explored
+func (l *Level) Explore(visible *grid.VisibilityMask) {
+	l.Explored.Merge(visible)
+	for y := 0; y < l.Grid.Height; y++ {
+		for x := 0; x < l.Grid.Width; x++ {
+			if !visible.IsVisible(x, y) {
+				continue
+			}
+			if cell, err := l.Grid.Get(x, y); err == nil {
+				l.Memory.Terrain.Set(x, y, cell)
+			}
+			l.Memory.Items.Replace(x, y, l.Items.At(x, y))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package dungeon
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+func TestItemsTakenOutOfSightAreRemembered(t *testing.T) {
+	g := grid.NewSolidGridOfType(5, 1, grid.ROOM)
+	level := &Level{1, g, &grid.Metadata{}, nil, item.NewLayer(), grid.NewVisibilityMask(5, 1), NewMemory(5, 1)}
+	dagger := &item.Item{Kind: item.WEAPON, Name: "dagger", Amount: 1, Slot: item.WEAPON_SLOT}
+	level.Items.Put(3, 0, dagger)
+
+	level.Explore(g.FieldOfView(0, 0, 8, nil))
+	level.Items.Take(3, 0)
+	g.ApplyToCellAtXY(grid.GridCellTypeConverter(grid.DOOR_CLOSED), 4, 0)
+	level.Explore(grid.NewVisibilityMask(5, 1))
+
+	if top := level.Memory.Items.Top(3, 0); top != dagger {
+		t.Errorf("remembered %v at (3,0), want the dagger taken out of sight", top)
+	}
+	if !level.Memory.Terrain.TestCellAtXY(grid.GridCellIsOfType(grid.ROOM), 4, 0) {
+		t.Error("door built out of sight is remembered")
+	}
+
+	level.Explore(g.FieldOfView(0, 0, 8, nil))
+	if top := level.Memory.Items.Top(3, 0); top != nil {
+		t.Errorf("remembered %v at (3,0) after seeing it gone", top)
+	}
+	if !level.Memory.Terrain.TestCellAtXY(grid.GridCellIsOfType(grid.DOOR_CLOSED), 4, 0) {
+		t.Error("door seen is not remembered")
+	}
+}
@@ -0,0 +1,217 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/dungeon"
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+	"github.com/mahe-go/grogue/util"
+)
+
+var GAME_OVER = errors.New("Game over")
+
+// Squeeze rule of new games: only large creatures are too big to pass between two walls diagonally
+const DEFAULT_SQUEEZING = grid.SQUEEZE_FORBIDDEN_FOR_LARGE
+
+// Everything that makes up a game session
+type Game struct {
+	Dungeon   *dungeon.Dungeon
+	Player    *creature.Player
+	Scheduler *creature.Scheduler
+	Kills     int
+	KilledBy  string
+	Log       *MessageLog
+	Squeezing grid.SqueezeRule
+	// Source of the random numbers of the game, saved so that a resumed game plays out like it would have without saving
+	Random *util.Source
+	rng    *rand.Rand
+	events []creature.Event
+}
+
+// Return a new game with player standing on the staircase up of the first level
+func NewGame(width int, height int, seed int64, config grid.GeneratorConfiguration, player *creature.Player) (*Game, error) {
+	d, err := dungeon.NewDungeon(width, height, seed, config)
+	if err != nil {
+		return nil, err
+	}
+	start := d.Current().Metadata.StairsUp
+	player.SetLocation(start.X, start.Y)
+	source := util.NewSource(seed)
+	g := &Game{d, player, creature.NewScheduler(), 0, "", NewMessageLog(MESSAGE_HISTORY), DEFAULT_SQUEEZING, source, rand.New(source), nil}
+	g.message(fmt.Sprintf("Welcome to the dungeon, %s.", player.Name), INFO)
+	g.explore()
+	return g, nil
+}
+
+// Return the level the player is on
+func (g *Game) Level() *dungeon.Level {
+	return g.Dungeon.Current()
+}
+
+// Return the cells the player currently sees
+func (g *Game) FieldOfView() *grid.VisibilityMask {
+	return g.Level().Grid.FieldOfView(g.Player.X, g.Player.Y, g.Player.Stats().Sight, nil)
+}
+
+// Remember the cells the player currently sees as explored, and what is on them
+func (g *Game) explore() {
+	g.Level().Explore(g.FieldOfView())
+}
+
+// Return the world of the level the player is on
+func (g *Game) World() *creature.World {
+	level := g.Level()
+	return &creature.World{Grid: level.Grid, Player: g.Player, Monsters: level.Monsters, Rng: g.rng, Report: g.report, Squeezing: g.Squeezing}
+}
+
+func (g *Game) report(e creature.Event) {
+	if e.Kind == creature.DEATH {
+		if e.Target == creature.Actor(g.Player) && e.Actor == nil {
+			// poison is the only status effect that kills
+			g.KilledBy = "poison"
+		} else if e.Target == creature.Actor(g.Player) {
+			g.KilledBy = e.Actor.Describe()
+		} else if e.Actor == creature.Actor(g.Player) {
+			g.Kills++
+		}
+	}
+	if text, severity := g.describe(e); text != "" {
+		g.message(text, severity)
+	}
+	g.events = append(g.events, e)
+}
+
+// Return events that happened since last call
+func (g *Game) Events() []creature.Event {
+	events := g.events
+	g.events = nil
+	return events
+}
+
+// Return true if the run has ended with the death of the player
+func (g *Game) IsOver() bool {
+	return !g.Player.IsAlive()
+}
+
+// Return a summary of the run
+func (g *Game) Summary() string {
+	if g.IsOver() {
+		return fmt.Sprintf("%s was killed by the %s on depth %d after %d turns, having slain %d monsters.",
+			g.Player.Name, g.KilledBy, g.Dungeon.Depth, g.Scheduler.Turn(), g.Kills)
+	}
+	return fmt.Sprintf("%s is alive on depth %d after %d turns, having slain %d monsters.",
+		g.Player.Name, g.Dungeon.Depth, g.Scheduler.Turn(), g.Kills)
+}
+
+// Let the player do action in the world of the current level, then let the monsters act.
+// A failed action takes no time, and the player is told why it failed.
+func (g *Game) playerAction(action func(w *creature.World) error) error {
+	if g.IsOver() {
+		return GAME_OVER
+	}
+	if err := action(g.World()); err != nil {
+		return g.fail(err)
+	}
+	g.EndTurn()
+	return nil
+}
+
+// Move player one step to direction, attacking any monster there. Monsters act afterwards, unless the move failed.
+func (g *Game) MovePlayer(direction grid.Direction) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.Move(w, direction)
+	})
+}
+
+// Pick up the topmost item under the player
+func (g *Game) PickUp() error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.PickUp(w, g.Level().Items)
+	})
+}
+
+// Drop the item at index of the player's inventory
+func (g *Game) Drop(index int) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.Drop(w, g.Level().Items, index)
+	})
+}
+
+// Wield or wear the item at index of the player's inventory
+func (g *Game) Equip(index int) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.Equip(w, index)
+	})
+}
+
+// Take off the item the player has in slot
+func (g *Game) Unequip(slot item.Slot) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.Unequip(w, slot)
+	})
+}
+
+// Open the door next to the player in direction
+func (g *Game) OpenDoor(direction grid.Direction) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.OpenDoor(w, direction)
+	})
+}
+
+// Close the door next to the player in direction
+func (g *Game) CloseDoor(direction grid.Direction) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.CloseDoor(w, g.Level().Items, direction)
+	})
+}
+
+// Unlock the door next to the player in direction
+func (g *Game) UnlockDoor(direction grid.Direction) error {
+	return g.playerAction(func(w *creature.World) error {
+		return g.Player.UnlockDoor(w, direction)
+	})
+}
+
+// Take the staircase down the player stands on
+func (g *Game) Descend() error {
+	if g.IsOver() {
+		return GAME_OVER
+	}
+	arrival, err := g.Dungeon.Descend(g.Player.X, g.Player.Y)
+	if err != nil {
+		return g.fail(err)
+	}
+	g.message(fmt.Sprintf("You descend to depth %d.", g.Dungeon.Depth), INFO)
+	g.arrive(arrival)
+	return nil
+}
+
+// Take the staircase up the player stands on
+func (g *Game) Ascend() error {
+	if g.IsOver() {
+		return GAME_OVER
+	}
+	arrival, err := g.Dungeon.Ascend(g.Player.X, g.Player.Y)
+	if err != nil {
+		return g.fail(err)
+	}
+	g.message(fmt.Sprintf("You climb up to depth %d.", g.Dungeon.Depth), INFO)
+	g.arrive(arrival)
+	return nil
+}
+
+func (g *Game) arrive(at grid.Point) {
+	g.Player.SetLocation(at.X, at.Y)
+	g.Player.Spend(creature.STAIRS_COST)
+	g.EndTurn()
+}
+
+// Let time pass on the player's level until the player can act again, then remember what the player sees
+func (g *Game) EndTurn() {
+	g.Scheduler.RunUntilPlayerReady(g.World())
+	g.explore()
+}
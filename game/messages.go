@@ -0,0 +1,156 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/dungeon"
+	"github.com/mahe-go/grogue/item"
+)
+
+// Number of messages kept in history
+const MESSAGE_HISTORY = 200
+
+type Severity int
+
+const (
+	INFO Severity = iota
+	GOOD
+	WARNING
+	DANGER
+)
+
+type Message struct {
+	Text     string
+	Severity Severity
+	Turn     int
+}
+
+// Bounded history of messages, oldest first
+type MessageLog struct {
+	Messages []Message
+	Capacity int
+}
+
+func NewMessageLog(capacity int) *MessageLog {
+	return &MessageLog{[]Message{}, capacity}
+}
+
+// Add message, forgetting the oldest one if the log is full
+func (l *MessageLog) Add(text string, severity Severity, turn int) {
+	l.Messages = append(l.Messages, Message{text, severity, turn})
+	if len(l.Messages) > l.Capacity {
+		l.Messages = l.Messages[len(l.Messages)-l.Capacity:]
+	}
+}
+
+// Return the last n messages, oldest first
+func (l *MessageLog) Last(n int) []Message {
+	if n > len(l.Messages) {
+		n = len(l.Messages)
+	}
+	return l.Messages[len(l.Messages)-n:]
+}
+
+func (g *Game) message(text string, severity Severity) {
+	g.Log.Add(text, severity, g.Scheduler.Turn())
+}
+
+// Feedback for actions the player couldn't take
+var failureMessages = map[error]string{
+	creature.CANNOT_MOVE_THERE: "You can't move there.",
+	dungeon.NOT_ON_STAIRCASE:   "There is no staircase here.",
+	dungeon.NO_LEVEL_ABOVE:     "The way up is blocked.",
+	item.NOTHING_HERE:          "There is nothing here to pick up.",
+	item.INVENTORY_FULL:        "You can't carry any more.",
+	item.NO_SUCH_ITEM:          "You don't have that.",
+	item.NOT_EQUIPPABLE:        "You can't wield or wear that.",
+	item.SLOT_EMPTY:            "You have nothing there.",
+	creature.NO_DOOR:           "There is no suitable door there.",
+	creature.DOOR_IS_LOCKED:    "The door is locked.",
+	creature.DOORWAY_BLOCKED:   "Something is in the doorway.",
+}
+
+// Tell the player why an action failed, and return err
+func (g *Game) fail(err error) error {
+	if text, ok := failureMessages[err]; ok {
+		g.message(text, WARNING)
+	}
+	return err
+}
+
+// Return how actor is referred to in messages
+func (g *Game) name(a creature.Actor) string {
+	if a == creature.Actor(g.Player) {
+		return "you"
+	}
+	return "the " + a.Describe()
+}
+
+// Describe event as a message, or return an empty text if the event is not worth a message
+func (g *Game) describe(e creature.Event) (string, Severity) {
+	byPlayer := e.Actor != nil && e.Actor == creature.Actor(g.Player)
+	toPlayer := e.Target != nil && e.Target == creature.Actor(g.Player)
+	switch e.Kind {
+	case creature.HIT:
+		if toPlayer {
+			return capitalize(fmt.Sprintf("%s hits you for %d.", g.name(e.Actor), e.Amount)), DANGER
+		}
+		return capitalize(fmt.Sprintf("%s hit %s for %d.", g.name(e.Actor), g.name(e.Target), e.Amount)), INFO
+	case creature.MISS:
+		if byPlayer {
+			return fmt.Sprintf("You miss %s.", g.name(e.Target)), INFO
+		}
+		return capitalize(fmt.Sprintf("%s misses %s.", g.name(e.Actor), g.name(e.Target))), INFO
+	case creature.DEATH:
+		if toPlayer {
+			return "You die...", DANGER
+		} else if byPlayer {
+			return fmt.Sprintf("You kill %s.", g.name(e.Target)), GOOD
+		} else if e.Actor == nil {
+			return capitalize(fmt.Sprintf("%s dies of %s.", g.name(e.Target), e.Effect)), GOOD
+		}
+		return capitalize(fmt.Sprintf("%s dies.", g.name(e.Target))), INFO
+	case creature.PICK_UP:
+		return fmt.Sprintf("You pick up %s.", e.Item.Describe()), INFO
+	case creature.DROP:
+		return fmt.Sprintf("You drop %s.", e.Item.Describe()), INFO
+	case creature.EQUIP:
+		if e.Item.Kind == item.WEAPON {
+			return fmt.Sprintf("You wield %s.", e.Item.Describe()), INFO
+		}
+		return fmt.Sprintf("You put on %s.", e.Item.Describe()), INFO
+	case creature.UNEQUIP:
+		return fmt.Sprintf("You take off %s.", e.Item.Describe()), INFO
+	case creature.OPEN_DOOR:
+		// monsters opening doors show on the map when the player sees them
+		if byPlayer {
+			return "You open the door.", INFO
+		}
+	case creature.CLOSE_DOOR:
+		return "You close the door.", INFO
+	case creature.UNLOCK_DOOR:
+		return "You pick the lock.", GOOD
+	case creature.EFFECT_STARTED:
+		if toPlayer {
+			return fmt.Sprintf("You are %s!", e.Effect), DANGER
+		}
+		return capitalize(fmt.Sprintf("%s is %s.", g.name(e.Target), e.Effect)), INFO
+	case creature.POISON_DAMAGE:
+		if toPlayer {
+			return fmt.Sprintf("The poison hurts you for %d.", e.Amount), DANGER
+		}
+	case creature.EFFECT_EXPIRED:
+		if toPlayer {
+			return fmt.Sprintf("You are no longer %s.", e.Effect), GOOD
+		}
+	}
+	return "", INFO
+}
+
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
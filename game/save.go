@@ -0,0 +1,83 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+
+	"github.com/mahe-go/grogue/dungeon"
+	"github.com/mahe-go/grogue/grid"
+)
+
+// Version of the save format written by Save. Bump it whenever the format changes, saves of any other version can't be resumed.
+const SAVE_VERSION = 1
+
+var UNSUPPORTED_SAVE_VERSION = errors.New("Unsupported save version")
+var MALFORMED_SAVE = errors.New("Malformed save")
+
+type saveFile struct {
+	Version int
+	Game    json.RawMessage
+}
+
+// Write game to w
+func (g *Game) Save(w io.Writer) error {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(saveFile{SAVE_VERSION, data})
+}
+
+// Read game from r. Games saved in another format are rejected with UNSUPPORTED_SAVE_VERSION,
+// and games missing any of their parts with MALFORMED_SAVE.
+func Load(r io.Reader) (*Game, error) {
+	var save saveFile
+	if err := json.NewDecoder(r).Decode(&save); err != nil {
+		return nil, err
+	}
+	if save.Version != SAVE_VERSION {
+		return nil, UNSUPPORTED_SAVE_VERSION
+	}
+	g := &Game{}
+	if err := json.Unmarshal(save.Game, g); err == dungeon.MALFORMED_DUNGEON || err == grid.MALFORMED_GRID {
+		return nil, MALFORMED_SAVE
+	} else if err != nil {
+		return nil, err
+	}
+	if g.Dungeon == nil || g.Player == nil || g.Player.Species == nil || g.Player.Inventory == nil ||
+		g.Scheduler == nil || g.Log == nil || g.Random == nil {
+		return nil, MALFORMED_SAVE
+	}
+	g.rng = rand.New(g.Random)
+	return g, nil
+}
+
+// Write game to file at path, replacing any previous save
+func (g *Game) SaveFile(path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err = g.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Read game from file at path
+func LoadFile(path string) (*Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
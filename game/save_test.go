@@ -0,0 +1,115 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/grid"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	species := creature.HUMAN
+	gm, err := NewGame(60, 20, 42, grid.DefaultGeneratorConfiguration, creature.NewPlayer("Mahe", &species))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := gm.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Level().Grid.String() != gm.Level().Grid.String() {
+		t.Error("level differs after loading")
+	}
+	if *loaded.Player.Species != *gm.Player.Species || loaded.Player.X != gm.Player.X || loaded.Player.Y != gm.Player.Y {
+		t.Errorf("player %+v differs after loading from %+v", loaded.Player, gm.Player)
+	}
+}
+
+func TestOtherSaveVersionsAreRejected(t *testing.T) {
+	for _, version := range []int{0, SAVE_VERSION + 1} {
+		save := fmt.Sprintf(`{"Version":%d,"Game":{}}`, version)
+		if _, err := Load(strings.NewReader(save)); err != UNSUPPORTED_SAVE_VERSION {
+			t.Errorf("version %d: got %v, want %v", version, err, UNSUPPORTED_SAVE_VERSION)
+		}
+	}
+}
+
+func TestSaveMissingPartsIsRejected(t *testing.T) {
+	species := creature.HUMAN
+	gm, err := NewGame(30, 12, 7, grid.DefaultGeneratorConfiguration, creature.NewPlayer("Mahe", &species))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(gm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := map[string]func(game map[string]interface{}){}
+	for _, key := range []string{"Dungeon", "Player", "Scheduler", "Log", "Random"} {
+		key := key
+		missing[key] = func(game map[string]interface{}) { delete(game, key) }
+	}
+	for _, key := range []string{"Items", "Metadata"} {
+		key := key
+		missing["level "+key] = func(game map[string]interface{}) {
+			level := game["Dungeon"].(map[string]interface{})["Levels"].([]interface{})[0]
+			delete(level.(map[string]interface{}), key)
+		}
+	}
+	for name, remove := range missing {
+		var game map[string]interface{}
+		if err := json.Unmarshal(data, &game); err != nil {
+			t.Fatal(err)
+		}
+		remove(game)
+		save, err := json.Marshal(map[string]interface{}{"Version": SAVE_VERSION, "Game": game})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(bytes.NewReader(save)); err != MALFORMED_SAVE {
+			t.Errorf("without %s: got %v, want %v", name, err, MALFORMED_SAVE)
+		}
+	}
+}
+
+func TestResumedGamePlaysOutLikeTheOriginal(t *testing.T) {
+	species := creature.HUMAN
+	// sturdy enough to live through the monsters, so that they keep drawing random numbers
+	species.HitPoints = 10000
+	original, err := NewGame(60, 20, 42, grid.DefaultGeneratorConfiguration, creature.NewPlayer("Mahe", &species))
+	if err != nil {
+		t.Fatal(err)
+	}
+	play := func(gm *Game) string {
+		for i := 0; i < 40; i++ {
+			if err := gm.MovePlayer(grid.AllDirections[i*3%len(grid.AllDirections)]); err == GAME_OVER {
+				t.Fatal(err)
+			}
+		}
+		var buf bytes.Buffer
+		if err := gm.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	play(original)
+	var saved bytes.Buffer
+	if err := original.Save(&saved); err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := Load(bytes.NewReader(saved.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if play(original) != play(resumed) {
+		t.Error("resumed game played out differently")
+	}
+}
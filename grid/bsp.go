@@ -2,28 +2,16 @@ package grid
 
 import (
 	"math/rand"
-	"time"
 )
 
-type rect struct {
-	X      int
-	Y      int
-	Width  int
-	Height int
-}
-
-func newRect(x int, y int, width int, height int) *rect {
-	return &rect{x, y, width, height}
-}
-
 type node struct {
 	Parent *node
-	Rect   *rect
+	Rect   *Rect
 	Left   *node
 	Right  *node
 }
 
-func newNode(parent *node, r *rect) *node {
+func newNode(parent *node, r *Rect) *node {
 	return &node{parent, r, nil, nil}
 }
 
@@ -31,22 +19,37 @@ func (n *node) isLeaf() bool {
 	return n.Left == nil && n.Right == nil
 }
 
-// Constructor for cavern with rectangular rooms
-func NewRectangularCavernGrid(width int, height int, minNodeWidth int, minNodeHeight int) *Grid {
-	rand.Seed(time.Now().Unix())
-	root := split(newNode(nil, newRect(1, 1, width-1, height-1)), minNodeWidth, minNodeHeight)
+// Constructor for cavern with rectangular rooms. The same rng state always produces the same cavern.
+func NewRectangularCavernGrid(width int, height int, minNodeWidth int, minNodeHeight int, rng *rand.Rand) (*Grid, error) {
+	grid, _, err := newRectangularCavern(width, height, minNodeWidth, minNodeHeight, rng)
+	return grid, err
+}
+
+func newRectangularCavern(width int, height int, minNodeWidth int, minNodeHeight int, rng *rand.Rand) (*Grid, *Metadata, error) {
+	root := split(newNode(nil, newRect(1, 1, width-1, height-1)), minNodeWidth, minNodeHeight, rng)
 	grid := NewSolidGridOfType(width, height, SOLID_ROCK)
-	root.delveRoom(grid)
-	root.connectPartsWithCorridor(grid)
+	metadata := &Metadata{}
+	root.delveRoom(grid, metadata, rng)
+	root.connectPartsWithCorridor(grid, metadata)
+	metadata.placeDoors(grid, rng)
 
 	grid.buildCavernWalls()
 
-	grid.AddStairCases()
+	var err error
+	if metadata.StairsUp, metadata.StairsDown, err = grid.AddStairCases(rng); err != nil {
+		return nil, nil, err
+	}
+	if dug := grid.EnsureConnected(metadata.StairsUp, metadata.StairsDown); len(dug) > 0 {
+		if corridor := metadata.placeEntrances(grid, Corridor{Cells: dug}, rng); len(corridor.Cells) > 0 {
+			metadata.Corridors = append(metadata.Corridors, corridor)
+		}
+	}
+	metadata.link()
 
-	return grid
+	return grid, metadata, nil
 }
 
-func split(n *node, minNodeWidth int, minNodeHeight int) *node {
+func split(n *node, minNodeWidth int, minNodeHeight int, rng *rand.Rand) *node {
 	r := n.Rect
 	var width, height, width2, height2 int
 	var x, y int
@@ -58,7 +61,7 @@ func split(n *node, minNodeWidth int, minNodeHeight int) *node {
 		return n
 	}
 
-	direction := rand.Intn(2)
+	direction := rng.Intn(2)
 	if verticalSplitPossible && !horizontalSplitPossible {
 		direction = 0
 	} else if !verticalSplitPossible && horizontalSplitPossible {
@@ -66,7 +69,7 @@ func split(n *node, minNodeWidth int, minNodeHeight int) *node {
 	}
 
 	if direction == 0 {
-		splitLoc := minNodeWidth + rand.Intn(r.Width-2*minNodeWidth)
+		splitLoc := minNodeWidth + rng.Intn(r.Width-2*minNodeWidth)
 		width = splitLoc
 		x = r.X + width
 		width2 = r.Width - width
@@ -74,7 +77,7 @@ func split(n *node, minNodeWidth int, minNodeHeight int) *node {
 		height2 = r.Height
 		y = r.Y
 	} else {
-		splitLoc := minNodeHeight + rand.Intn(r.Height-2*minNodeHeight)
+		splitLoc := minNodeHeight + rng.Intn(r.Height-2*minNodeHeight)
 		width = r.Width
 		width2 = r.Width
 		x = r.X
@@ -86,49 +89,229 @@ func split(n *node, minNodeWidth int, minNodeHeight int) *node {
 	leftRect := newRect(r.X, r.Y, width, height)
 	rightRect := newRect(x, y, width2, height2)
 
-	n.Left = split(newNode(n, leftRect), minNodeHeight, minNodeWidth)
-	n.Right = split(newNode(n, rightRect), minNodeHeight, minNodeWidth)
+	n.Left = split(newNode(n, leftRect), minNodeHeight, minNodeWidth, rng)
+	n.Right = split(newNode(n, rightRect), minNodeHeight, minNodeWidth, rng)
 
 	return n
 }
 
-func (n *node) delveRoom(grid *Grid) {
+// Dig a room in every leaf and add the rooms to metadata
+func (n *node) delveRoom(grid *Grid, metadata *Metadata, rng *rand.Rand) {
 	if n.isLeaf() {
-		roomWidth := n.Rect.Width/2 + rand.Intn(n.Rect.Width/2)
-		roomHeight := n.Rect.Height/2 + rand.Intn(n.Rect.Height/2)
+		roomWidth := n.Rect.Width/2 + rng.Intn(n.Rect.Width/2)
+		roomHeight := n.Rect.Height/2 + rng.Intn(n.Rect.Height/2)
 
 		roomX := 0
 		if roomWidth < n.Rect.Width {
-			roomX = rand.Intn(n.Rect.Width - roomWidth)
+			roomX = rng.Intn(n.Rect.Width - roomWidth)
 		}
 
 		roomY := 0
 		if roomWidth < n.Rect.Height {
-			roomY = rand.Intn(n.Rect.Height - roomHeight)
+			roomY = rng.Intn(n.Rect.Height - roomHeight)
 		}
 
 		var err error
+		var cells []Point
 		for x := roomX; x < roomWidth && err == nil; x++ {
 			for y := roomY; y < roomHeight && err == nil; y++ {
 				err = grid.ApplyToCellAtXY(GridCellTypeConverter(ROOM), n.Rect.X+x, n.Rect.Y+y)
+				if err == nil {
+					cells = append(cells, Point{n.Rect.X + x, n.Rect.Y + y})
+				}
 			}
 		}
+		if len(cells) > 0 {
+			metadata.Rooms = append(metadata.Rooms, newRoom(cells))
+		}
 		return
 	}
-	n.Left.delveRoom(grid)
-	n.Right.delveRoom(grid)
+	n.Left.delveRoom(grid, metadata, rng)
+	n.Right.delveRoom(grid, metadata, rng)
+}
+
+// Put doors where the corridors enter rooms: mostly closed, some open and a few locked.
+// Nothing separates a corridor running along the side of a room from it, so such stretches become part of the room
+// and the corridor enters the room at their ends. Placing doors can make more corridor cells part of a room,
+// so both are repeated until every corridor meets rooms only through its doors.
+func (m *Metadata) placeDoors(g *Grid, rng *rand.Rand) {
+	for merged := true; merged; {
+		for m.mergeCorridorsAlongRooms(g) {
+		}
+		merged = false
+		var entered []Corridor
+		for _, corridor := range m.Corridors {
+			left := m.placeEntrances(g, corridor, rng)
+			merged = merged || len(left.Cells) < len(corridor.Cells)
+			if len(left.Cells) > 0 {
+				entered = append(entered, left)
+			}
+		}
+		m.Corridors = entered
+	}
 }
 
-func (n *node) connectPartsWithCorridor(grid *Grid) {
+// Make the cells of straight corridors with a room beside them, or on opposite sides of them, part of that room,
+// splitting the corridors around them.
+// Return true if any cell was merged, which can put a room beside more corridors.
+func (m *Metadata) mergeCorridorsAlongRooms(g *Grid) bool {
+	roomOf := m.roomOf()
+	merged := false
+	var corridors []Corridor
+	for _, corridor := range m.Corridors {
+		var kept []Point
+		for i, c := range corridor.Cells {
+			room, ok := roomAlongside(roomOf, corridor.Cells, i)
+			if !ok {
+				room, ok = roomAround(roomOf, c)
+			}
+			if !ok {
+				kept = append(kept, c)
+				continue
+			}
+			g.ApplyToCellAtXY(GridCellTypeConverter(ROOM), c.X, c.Y)
+			m.Rooms[room].Cells = append(m.Rooms[room].Cells, c)
+			roomOf[c] = room
+			merged = true
+			if len(kept) > 0 {
+				corridors = append(corridors, Corridor{Cells: kept})
+				kept = nil
+			}
+		}
+		if len(kept) > 0 {
+			corridors = append(corridors, Corridor{Cells: kept})
+		}
+	}
+	m.Corridors = corridors
+	for i, room := range m.Rooms {
+		m.Rooms[i] = newRoom(room.Cells)
+	}
+	return merged
+}
+
+// Put a door at each end of corridor meeting a room orthogonally, and return what is left of corridor.
+// An end next to another door, such as the far end of a corridor of two cells between two rooms,
+// becomes part of the room instead, so that the corridor meets the room one cell earlier.
+func (m *Metadata) placeEntrances(g *Grid, corridor Corridor, rng *rand.Rand) Corridor {
+	roomOf := m.roomOf()
+	for {
+		absorbed := false
+		for _, end := range corridor.ends() {
+			room, ok := roomNextTo(roomOf, end)
+			if !ok || g.TestCellAtXY(CellIsDoor, end.X, end.Y) {
+				continue
+			}
+			if !nextToDoor(g, end) {
+				g.ApplyToCellAtXY(GridCellTypeConverter(randomDoor(rng)), end.X, end.Y)
+				continue
+			}
+			g.ApplyToCellAtXY(GridCellTypeConverter(ROOM), end.X, end.Y)
+			m.Rooms[room] = newRoom(append(m.Rooms[room].Cells, end))
+			roomOf[end] = room
+			corridor.Cells = removePoint(corridor.Cells, end)
+			absorbed = true
+			break
+		}
+		if !absorbed {
+			return corridor
+		}
+	}
+}
+
+// Return the room orthogonally next to p
+func roomNextTo(roomOf map[Point]int, p Point) (int, bool) {
+	for _, d := range CardinalDirections {
+		if room, ok := roomOf[p.Step(d)]; ok {
+			return room, true
+		}
+	}
+	return 0, false
+}
+
+func nextToDoor(g *Grid, p Point) bool {
+	for _, d := range AllDirections {
+		if g.TestCellAtXY(CellIsDoor, p.X+d.Dx, p.Y+d.Dy) {
+			return true
+		}
+	}
+	return false
+}
+
+func removePoint(points []Point, p Point) []Point {
+	var kept []Point
+	for _, q := range points {
+		if q != p {
+			kept = append(kept, q)
+		}
+	}
+	return kept
+}
+
+// Return the room beside the i-th cell of a straight corridor, across the direction the corridor runs in
+func roomAlongside(roomOf map[Point]int, cells []Point, i int) (int, bool) {
+	var along Direction
+	switch {
+	case i+1 < len(cells):
+		along = Direction{cells[i+1].X - cells[i].X, cells[i+1].Y - cells[i].Y}
+	case i > 0:
+		along = Direction{cells[i].X - cells[i-1].X, cells[i].Y - cells[i-1].Y}
+	default:
+		return 0, false
+	}
+	for _, across := range []Direction{{-along.Dy, along.Dx}, {along.Dy, -along.Dx}} {
+		if room, ok := roomOf[cells[i].Step(across)]; ok {
+			return room, true
+		}
+	}
+	return 0, false
+}
+
+// Return the room on opposite sides of p
+func roomAround(roomOf map[Point]int, p Point) (int, bool) {
+	for _, d := range []Direction{North, East} {
+		room, ok := roomOf[p.Step(d)]
+		if other, across := roomOf[Point{p.X - d.Dx, p.Y - d.Dy}]; ok && across && other == room {
+			return room, true
+		}
+	}
+	return 0, false
+}
+
+func randomDoor(rng *rand.Rand) CellType {
+	switch roll := rng.Intn(10); {
+	case roll == 0:
+		return DOOR_LOCKED
+	case roll < 4:
+		return DOOR_OPEN
+	default:
+		return DOOR_CLOSED
+	}
+}
+
+// Connect the parts of every node with a corridor and add the corridors to metadata.
+// A line between the parts may cross rooms and earlier corridors, so it can be split in several corridors.
+func (n *node) connectPartsWithCorridor(grid *Grid, metadata *Metadata) {
 	if !n.isLeaf() {
-		n.Left.connectPartsWithCorridor(grid)
-		n.Right.connectPartsWithCorridor(grid)
+		n.Left.connectPartsWithCorridor(grid, metadata)
+		n.Right.connectPartsWithCorridor(grid, metadata)
 
 		startx := n.Left.Rect.X + n.Left.Rect.Width/2
 		endx := n.Right.Rect.X + n.Right.Rect.Width/2
 		starty := n.Left.Rect.Y + n.Left.Rect.Height/2
 		endy := n.Right.Rect.Y + n.Right.Rect.Height/2
 
-		grid.ApplyOnLine(GridCellTypeConverter(CORRIDOR), GridCellIsOfType(SOLID_ROCK), startx, starty, endx, endy)
+		var dug []Point
+		for _, p := range grid.Line(startx, starty, endx, endy) {
+			if grid.TestCellAtXY(GridCellIsOfType(SOLID_ROCK), p.X, p.Y) {
+				grid.ApplyToCellAtXY(GridCellTypeConverter(CORRIDOR), p.X, p.Y)
+				dug = append(dug, p)
+			} else if len(dug) > 0 {
+				metadata.Corridors = append(metadata.Corridors, Corridor{Cells: dug})
+				dug = nil
+			}
+		}
+		if len(dug) > 0 {
+			metadata.Corridors = append(metadata.Corridors, Corridor{Cells: dug})
+		}
 	}
 }
@@ -0,0 +1,47 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/util"
+)
+
+// Call check for every cell of rectangular caverns generated from a range of seeds
+func forGeneratedCells(t *testing.T, check func(g *Grid, x int, y int) string) {
+	for seed := int64(0); seed < 200; seed++ {
+		g, _, err := newRectangularCavern(80, 24, 7, 7, util.NewRand(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				if problem := check(g, x, y); problem != "" {
+					t.Fatalf("seed %d: %s at (%d,%d)\n%s", seed, problem, x, y, g)
+				}
+			}
+		}
+	}
+}
+
+func TestCorridorsEnterRoomsThroughDoors(t *testing.T) {
+	forGeneratedCells(t, func(g *Grid, x int, y int) string {
+		if !g.TestCellAtXY(GridCellIsOfType(CORRIDOR), x, y) {
+			return ""
+		}
+		for _, d := range CardinalDirections {
+			if g.TestCellAtXY(GridCellIsOfType(ROOM), x+d.Dx, y+d.Dy) {
+				return "corridor touches a room without a door"
+			}
+		}
+		return ""
+	})
+}
+
+func TestDoorsAreNotNextToEachOther(t *testing.T) {
+	forGeneratedCells(t, func(g *Grid, x int, y int) string {
+		if g.TestCellAtXY(CellIsDoor, x, y) && nextToDoor(g, Point{x, y}) {
+			return "doors side by side"
+		}
+		return ""
+	})
+}
@@ -1,17 +1,39 @@
 package grid
 
+import "errors"
+
+var UNKNOWN_CELL_TYPE error = errors.New("Unknown cell type")
+
 type CellType struct {
 	Traversable bool
 	Rune        rune
 	Description string
+	Foreground  Colour
+	Background  Colour
 }
 
-var SOLID_ROCK = CellType{false, ' ', "solid rock"}
-var WALL = CellType{false, '#', "wall"}
-var ROOM = CellType{true, '.', "thin air"}
-var CORRIDOR = CellType{true, '.', "corridor"}
-var STAIRCASE_UP = CellType{true, '<', "staircase up"}
-var STAIRCASE_DOWN = CellType{true, '>', "staircase down"}
+var SOLID_ROCK = CellType{false, ' ', "solid rock", DEFAULT, DEFAULT}
+var WALL = CellType{false, '#', "wall", BLACK, WHITE}
+var ROOM = CellType{true, '.', "thin air", WHITE, DEFAULT}
+var CORRIDOR = CellType{true, '.', "corridor", CYAN, DEFAULT}
+var STAIRCASE_UP = CellType{true, '<', "staircase up", GREEN, DEFAULT}
+var STAIRCASE_DOWN = CellType{true, '>', "staircase down", GREEN, DEFAULT}
+var DOOR_OPEN = CellType{true, '\'', "open door", YELLOW, DEFAULT}
+var DOOR_CLOSED = CellType{false, '+', "closed door", YELLOW, DEFAULT}
+var DOOR_LOCKED = CellType{false, '&', "locked door", RED, DEFAULT}
+
+// All known cell types. Descriptions are unique, so they can be used to refer to types e.g. in save files.
+var CellTypes = []CellType{SOLID_ROCK, WALL, ROOM, CORRIDOR, STAIRCASE_UP, STAIRCASE_DOWN, DOOR_OPEN, DOOR_CLOSED, DOOR_LOCKED}
+
+// Return the cell type with description
+func CellTypeByDescription(description string) (CellType, error) {
+	for _, typ := range CellTypes {
+		if typ.Description == description {
+			return typ, nil
+		}
+	}
+	return CellType{}, UNKNOWN_CELL_TYPE
+}
 
 type GridCell struct {
 	Type    CellType
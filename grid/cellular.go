@@ -2,7 +2,6 @@ package grid
 
 import (
 	"math/rand"
-	"time"
 )
 
 type wrapper struct {
@@ -12,13 +11,18 @@ type wrapper struct {
 	shadow         *Grid
 }
 
-func newWrapper(width int, height int, emptySpacePercentage int, solidCellType CellType, hollowCellType CellType) *wrapper {
-	return &wrapper{solidCellType, hollowCellType, NewRandomGrid(width, height, emptySpacePercentage, solidCellType, hollowCellType), NewSolidGridOfType(width, height, hollowCellType)}
+func newWrapper(width int, height int, emptySpacePercentage int, solidCellType CellType, hollowCellType CellType, rng *rand.Rand) *wrapper {
+	return &wrapper{solidCellType, hollowCellType, NewRandomGrid(width, height, emptySpacePercentage, solidCellType, hollowCellType, rng), NewSolidGridOfType(width, height, hollowCellType)}
 }
 
-func NewNaturalCavernGrid(width int, height int, emptySpacePercentage int, cleanUpRounds int) *Grid {
-	rand.Seed(time.Now().Unix())
-	wrapper := newWrapper(width, height, emptySpacePercentage, SOLID_ROCK, ROOM)
+// Constructor for cavern generated with cellular automata. The same rng state always produces the same cavern.
+func NewNaturalCavernGrid(width int, height int, emptySpacePercentage int, cleanUpRounds int, rng *rand.Rand) (*Grid, error) {
+	grid, _, err := newNaturalCavern(width, height, emptySpacePercentage, cleanUpRounds, rng)
+	return grid, err
+}
+
+func newNaturalCavern(width int, height int, emptySpacePercentage int, cleanUpRounds int, rng *rand.Rand) (*Grid, *Metadata, error) {
+	wrapper := newWrapper(width, height, emptySpacePercentage, SOLID_ROCK, ROOM, rng)
 
 	for i := 0; i < cleanUpRounds; i++ {
 		wrapper.runRoundOfCellularAutomata()
@@ -26,11 +30,32 @@ func NewNaturalCavernGrid(width int, height int, emptySpacePercentage int, clean
 
 	fillUnreachableCaverns(wrapper)
 
+	// what is left is a single cavern, described as one irregular room
+	metadata := &Metadata{}
+	var cells []Point
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if wrapper.grid.TestCellAtXY(CellIsTraversable, x, y) {
+				cells = append(cells, Point{x, y})
+			}
+		}
+	}
+	if len(cells) > 0 {
+		metadata.Rooms = append(metadata.Rooms, newRoom(cells))
+	}
+
 	wrapper.grid.buildCavernWalls()
 
-	wrapper.grid.AddStairCases()
+	var err error
+	if metadata.StairsUp, metadata.StairsDown, err = wrapper.grid.AddStairCases(rng); err != nil {
+		return nil, nil, err
+	}
+	if dug := wrapper.grid.EnsureConnected(metadata.StairsUp, metadata.StairsDown); len(dug) > 0 {
+		metadata.Corridors = append(metadata.Corridors, Corridor{Cells: dug})
+	}
+	metadata.link()
 
-	return wrapper.grid
+	return wrapper.grid, metadata, nil
 }
 
 func (w *wrapper) runRoundOfCellularAutomata() {
@@ -52,24 +77,13 @@ func (w *wrapper) runRoundOfCellularAutomata() {
 	w.grid = tmp
 }
 
+// Fill every cavern but the largest one with solid cells
 func fillUnreachableCaverns(w *wrapper) {
-	isSuitableStartingPoint := func(x int, y int) bool {
-		return w.grid.TestCellAtXY(GridCellIsOfType(w.HollowCellType), x, y) &&
-			w.grid.CountNeighboursMatching(GridCellIsOfType(w.HollowCellType), x, y) == 8
-	}
-	var x, y int
-outer:
-	for y = 1; y < w.grid.Height; y++ {
-		for x = 1; x < w.grid.Width-1; x++ {
-			if isSuitableStartingPoint(x, y) {
-				break outer
-			}
-		}
+	components := w.grid.LabelComponents(GridCellIsOfType(w.HollowCellType), CardinalDirections)
+	largest, ok := components.Largest()
+	if !ok {
+		return
 	}
-
-	w.grid.ApplyToConnectedCells(GridCellChecker, GridCellIsChecked.Not().And(GridCellIsOfType(w.HollowCellType)), x, y)
-
-	w.grid.ApplyToAllMatchingCells(GridCellTypeConverter(w.SolidCellType), GridCellIsChecked.Not().And(GridCellIsOfType(w.HollowCellType)))
-
-	w.grid.ApplyToAllCells(GridCellUnChecker)
+	unreachable := GridCellIsOfType(w.HollowCellType).AtXY().And(components.Within(largest.Label).Not())
+	w.grid.ApplyToCellEverywhereMatching(GridCellTypeConverter(w.SolidCellType), unreachable)
 }
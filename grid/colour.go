@@ -0,0 +1,16 @@
+package grid
+
+// Terminal colour of a cell or of anything drawn on it, in ANSI order. DEFAULT keeps the terminal's own colour.
+type Colour int
+
+const (
+	DEFAULT Colour = iota
+	BLACK
+	RED
+	GREEN
+	YELLOW
+	BLUE
+	MAGENTA
+	CYAN
+	WHITE
+)
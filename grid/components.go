@@ -0,0 +1,121 @@
+package grid
+
+// Label of cells that belong to no component
+const NO_COMPONENT = -1
+
+// Connected area of cells, as labelled by LabelComponents
+type Component struct {
+	Label int
+	Size  int
+	// First cell of the component in row order
+	Representative Point
+}
+
+// Labelling of grid cells into connected components
+type ComponentMap struct {
+	labels     []int
+	Width      int
+	Height     int
+	Components []Component
+}
+
+// Return the connected components of cells matching condition, connected to each other through directions.
+// Components are labelled 0, 1, 2... in the row order of their representatives.
+func (g *Grid) LabelComponents(condition CellPredicate, directions []Direction) *ComponentMap {
+	m := &ComponentMap{make([]int, len(g.cells)), g.Width, g.Height, nil}
+	for i := range m.labels {
+		m.labels[i] = NO_COMPONENT
+	}
+	visited := make([]bool, len(g.cells))
+	for start := range g.cells {
+		cells := g.floodFill(condition, directions, start%g.Width, start/g.Width, visited)
+		if len(cells) == 0 {
+			continue
+		}
+		label := len(m.Components)
+		for _, c := range cells {
+			m.labels[c.Y*g.Width+c.X] = label
+		}
+		m.Components = append(m.Components, Component{label, len(cells), cells[0]})
+	}
+	return m
+}
+
+// Return the number of components
+func (m *ComponentMap) Count() int {
+	return len(m.Components)
+}
+
+// Return the label of the component (x,y) belongs to, or NO_COMPONENT
+func (m *ComponentMap) Label(x int, y int) int {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return NO_COMPONENT
+	}
+	return m.labels[y*m.Width+x]
+}
+
+// Return true if a and b belong to the same component
+func (m *ComponentMap) Connected(a Point, b Point) bool {
+	label := m.Label(a.X, a.Y)
+	return label != NO_COMPONENT && label == m.Label(b.X, b.Y)
+}
+
+// Return the component with the most cells, the first of them on ties. Returns false if there are no components.
+func (m *ComponentMap) Largest() (Component, bool) {
+	if len(m.Components) == 0 {
+		return Component{}, false
+	}
+	largest := m.Components[0]
+	for _, c := range m.Components[1:] {
+		if c.Size > largest.Size {
+			largest = c
+		}
+	}
+	return largest, true
+}
+
+// Return a LocationPredicate matching cells of the component labelled label
+func (m *ComponentMap) Within(label int) LocationPredicate {
+	return func(g *Grid, x int, y int) bool {
+		return m.Label(x, y) == label
+	}
+}
+
+// Cost of digging through a cell when connecting two points, relative to walking through a passable one
+const DIGGING_COST = 10
+
+// Make sure a and b are connected through passable cells, digging a corridor between them if they are not.
+// Connection is checked through cardinal directions only, so it holds however diagonal moves are restricted.
+// The corridor follows existing passages where that saves digging, and gets walls where it runs through solid rock.
+// Returns the cells dug, if any.
+func (g *Grid) EnsureConnected(a Point, b Point) []Point {
+	if g.LabelComponents(CellIsPassable, CardinalDirections).Connected(a, b) {
+		return nil
+	}
+	digging := func(cell GridCell) int {
+		if CellIsPassable(cell) {
+			return 1
+		}
+		return DIGGING_COST
+	}
+	anywhere := func(cell GridCell) bool {
+		return true
+	}
+	path, err := NewPathfinder(CardinalDirections, anywhere, digging).AStar(g, a.X, a.Y, b.X, b.Y)
+	if err != nil {
+		return nil
+	}
+	var dug []Point
+	for _, p := range path {
+		if !g.TestCellAtXY(CellIsPassable, p.X, p.Y) {
+			g.ApplyToCellAtXY(GridCellTypeConverter(CORRIDOR), p.X, p.Y)
+			dug = append(dug, p)
+		}
+	}
+	for _, p := range dug {
+		for _, d := range AllDirections {
+			g.ApplyToCellAtXYMatching(GridCellTypeConverter(WALL), GridCellIsOfType(SOLID_ROCK), p.X+d.Dx, p.Y+d.Dy)
+		}
+	}
+	return dug
+}
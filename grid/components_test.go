@@ -0,0 +1,48 @@
+package grid
+
+import "testing"
+
+const islands = `.#.#.
+.#...
+###.#
+..#.#`
+
+func TestLabelComponentsCountsSeparateAreas(t *testing.T) {
+	g, err := ParseGrid(islands, Legend{'.': ROOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cardinal := g.LabelComponents(CellIsTraversable, CardinalDirections)
+	if cardinal.Count() != 3 {
+		t.Errorf("got %d components, want 3", cardinal.Count())
+	}
+	if largest, _ := cardinal.Largest(); largest.Size != 7 || cardinal.Label(2, 0) != largest.Label {
+		t.Errorf("largest component %+v, want the 7 cells around (2,0)", largest)
+	}
+	if cardinal.Label(1, 0) != NO_COMPONENT {
+		t.Errorf("wall labelled %d", cardinal.Label(1, 0))
+	}
+	// the areas on the left do not touch the other one even diagonally
+	if eight := g.LabelComponents(CellIsTraversable, AllDirections); eight.Count() != 3 {
+		t.Errorf("got %d components connected in eight directions, want 3", eight.Count())
+	}
+}
+
+func TestEnsureConnectedDigsThroughTheWall(t *testing.T) {
+	g, err := ParseGrid(`...#...
+...#...`, Legend{'.': ROOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := Point{0, 0}, Point{6, 1}
+	dug := g.EnsureConnected(a, b)
+	if len(dug) != 1 || dug[0].X != 3 {
+		t.Errorf("dug %v, want one cell of the wall", dug)
+	}
+	if !g.LabelComponents(CellIsPassable, CardinalDirections).Connected(a, b) {
+		t.Error("still not connected")
+	}
+	if dug := g.EnsureConnected(a, b); dug != nil {
+		t.Errorf("dug %v when already connected", dug)
+	}
+}
@@ -0,0 +1,101 @@
+package grid
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var MALFORMED_GRID error = errors.New("Malformed grid")
+
+// Serialized form of a grid. Cell types are stored once in Types by description,
+// and each cell as an index to Types, row by row.
+type encodedGrid struct {
+	Width  int
+	Height int
+	Types  []string
+	Cells  []int
+}
+
+func (g *Grid) MarshalJSON() ([]byte, error) {
+	encoded := encodedGrid{g.Width, g.Height, []string{}, make([]int, len(g.cells))}
+	indices := make(map[string]int)
+	for i, cell := range g.cells {
+		index, ok := indices[cell.Type.Description]
+		if !ok {
+			index = len(encoded.Types)
+			indices[cell.Type.Description] = index
+			encoded.Types = append(encoded.Types, cell.Type.Description)
+		}
+		encoded.Cells[i] = index
+	}
+	return json.Marshal(encoded)
+}
+
+func (g *Grid) UnmarshalJSON(data []byte) error {
+	var encoded encodedGrid
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if encoded.Width < 0 || encoded.Height < 0 || len(encoded.Cells) != encoded.Width*encoded.Height {
+		return MALFORMED_GRID
+	}
+	types := make([]CellType, len(encoded.Types))
+	for i, description := range encoded.Types {
+		typ, err := CellTypeByDescription(description)
+		if err != nil {
+			return err
+		}
+		types[i] = typ
+	}
+	cells := make([]GridCell, len(encoded.Cells))
+	for i, index := range encoded.Cells {
+		if index < 0 || index >= len(types) {
+			return MALFORMED_GRID
+		}
+		cells[i] = NewGridCellOfTypeValue(types[index])
+	}
+	g.cells = cells
+	g.Width = encoded.Width
+	g.Height = encoded.Height
+	return nil
+}
+
+// Serialized form of a room. Cells are stored as a mask over the bounds, cells of the room as '1'
+// and others as '0', row by row, as caverns described as a single room can cover most of a level.
+type encodedRoom struct {
+	Bounds     Rect
+	Centre     Point
+	Cells      string
+	Corridors  []int
+	Neighbours []int
+}
+
+func (r *Room) MarshalJSON() ([]byte, error) {
+	cells := []byte(strings.Repeat("0", r.Bounds.Width*r.Bounds.Height))
+	for _, c := range r.Cells {
+		if !r.Bounds.Contains(c.X, c.Y) {
+			return nil, MALFORMED_GRID
+		}
+		cells[(c.Y-r.Bounds.Y)*r.Bounds.Width+c.X-r.Bounds.X] = '1'
+	}
+	return json.Marshal(encodedRoom{r.Bounds, r.Centre, string(cells), r.Corridors, r.Neighbours})
+}
+
+func (r *Room) UnmarshalJSON(data []byte) error {
+	var encoded encodedRoom
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if encoded.Bounds.Width < 0 || encoded.Bounds.Height < 0 || len(encoded.Cells) != encoded.Bounds.Width*encoded.Bounds.Height {
+		return MALFORMED_GRID
+	}
+	var cells []Point
+	for i := range encoded.Cells {
+		if encoded.Cells[i] == '1' {
+			cells = append(cells, Point{encoded.Bounds.X + i%encoded.Bounds.Width, encoded.Bounds.Y + i/encoded.Bounds.Width})
+		}
+	}
+	*r = Room{Bounds: encoded.Bounds, Centre: encoded.Centre, Cells: cells, Corridors: encoded.Corridors, Neighbours: encoded.Neighbours}
+	return nil
+}
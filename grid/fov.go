@@ -0,0 +1,194 @@
+package grid
+
+import "encoding/json"
+
+// Set of cells visible from a point, as computed by FieldOfView.
+// Masks can also be merged to remember every cell that has ever been visible.
+type VisibilityMask struct {
+	visible []bool
+	Width   int
+	Height  int
+}
+
+// Return a mask of width x height with no cell visible
+func NewVisibilityMask(width int, height int) *VisibilityMask {
+	return &VisibilityMask{make([]bool, width*height), width, height}
+}
+
+// Return true if (x,y) is visible
+func (m *VisibilityMask) IsVisible(x int, y int) bool {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return false
+	}
+	return m.visible[m.Width*y+x]
+}
+
+func (m *VisibilityMask) setVisible(x int, y int) {
+	if x >= 0 && x < m.Width && y >= 0 && y < m.Height {
+		m.visible[m.Width*y+x] = true
+	}
+}
+
+// Make every cell visible in other visible in m as well
+func (m *VisibilityMask) Merge(other *VisibilityMask) {
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if other.IsVisible(x, y) {
+				m.setVisible(x, y)
+			}
+		}
+	}
+}
+
+// Serialized form of a mask, visible cells as '1' and others as '0', row by row
+type encodedMask struct {
+	Width   int
+	Height  int
+	Visible string
+}
+
+func (m *VisibilityMask) MarshalJSON() ([]byte, error) {
+	cells := make([]byte, len(m.visible))
+	for i, visible := range m.visible {
+		if visible {
+			cells[i] = '1'
+		} else {
+			cells[i] = '0'
+		}
+	}
+	return json.Marshal(encodedMask{m.Width, m.Height, string(cells)})
+}
+
+func (m *VisibilityMask) UnmarshalJSON(data []byte) error {
+	var encoded encodedMask
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if encoded.Width < 0 || encoded.Height < 0 || len(encoded.Visible) != encoded.Width*encoded.Height {
+		return MALFORMED_GRID
+	}
+	m.visible = make([]bool, len(encoded.Visible))
+	for i := range encoded.Visible {
+		m.visible[i] = encoded.Visible[i] == '1'
+	}
+	m.Width = encoded.Width
+	m.Height = encoded.Height
+	return nil
+}
+
+// Return the mask as a LocationPredicate, so it can be combined with other conditions
+func (m *VisibilityMask) Predicate() LocationPredicate {
+	return func(g *Grid, x int, y int) bool {
+		return m.IsVisible(x, y)
+	}
+}
+
+// Slope of a line from the origin, as fraction num/den. den is always positive.
+type slope struct {
+	num int
+	den int
+}
+
+// One row of a quadrant being scanned, at distance depth from the origin
+type fovRow struct {
+	depth int
+	start slope
+	end   slope
+}
+
+// Transforms quadrant-relative (depth, col) coordinates to grid coordinates
+type quadrant func(depth int, col int) (int, int)
+
+// Compute the cells visible from (x,y) within radius using symmetric shadowcasting.
+// Cells matching opaque block sight but are themselves visible. If opaque is nil, CellBlocksSight is used.
+// Cells outside the grid block sight.
+func (g *Grid) FieldOfView(x int, y int, radius int, opaque CellPredicate) *VisibilityMask {
+	if opaque == nil {
+		opaque = CellBlocksSight
+	}
+	mask := NewVisibilityMask(g.Width, g.Height)
+	if _, err := g.cellIndex(x, y); err != nil {
+		return mask
+	}
+	mask.setVisible(x, y)
+
+	quadrants := []quadrant{
+		func(depth int, col int) (int, int) { return x + col, y - depth },
+		func(depth int, col int) (int, int) { return x + depth, y + col },
+		func(depth int, col int) (int, int) { return x + col, y + depth },
+		func(depth int, col int) (int, int) { return x - depth, y + col },
+	}
+	for _, q := range quadrants {
+		g.scanRow(mask, q, fovRow{1, slope{-1, 1}, slope{1, 1}}, radius, opaque)
+	}
+	return mask
+}
+
+func (g *Grid) scanRow(mask *VisibilityMask, q quadrant, row fovRow, radius int, opaque CellPredicate) {
+	if row.depth > radius {
+		return
+	}
+
+	isWall := func(col int) bool {
+		cx, cy := q(row.depth, col)
+		_, err := g.cellIndex(cx, cy)
+		return err != nil || g.TestCellAtXY(opaque, cx, cy)
+	}
+	reveal := func(col int) {
+		if row.depth*row.depth+col*col <= radius*radius {
+			mask.setVisible(q(row.depth, col))
+		}
+	}
+
+	minCol := roundTiesUp(row.depth*row.start.num, row.start.den)
+	maxCol := roundTiesDown(row.depth*row.end.num, row.end.den)
+
+	hasPrevious := false
+	previousIsWall := false
+	for col := minCol; col <= maxCol; col++ {
+		wall := isWall(col)
+		if wall || row.isSymmetric(col) {
+			reveal(col)
+		}
+		if hasPrevious && previousIsWall && !wall {
+			row.start = tileSlope(row.depth, col)
+		}
+		if hasPrevious && !previousIsWall && wall {
+			g.scanRow(mask, q, fovRow{row.depth + 1, row.start, tileSlope(row.depth, col)}, radius, opaque)
+		}
+		hasPrevious = true
+		previousIsWall = wall
+	}
+	if hasPrevious && !previousIsWall {
+		g.scanRow(mask, q, fovRow{row.depth + 1, row.start, row.end}, radius, opaque)
+	}
+}
+
+// A floor tile is only revealed if the center of it is within the row's slopes,
+// which makes the field of view symmetric.
+func (row fovRow) isSymmetric(col int) bool {
+	return col*row.start.den >= row.depth*row.start.num && col*row.end.den <= row.depth*row.end.num
+}
+
+// Slope of the left edge of tile at (depth, col)
+func tileSlope(depth int, col int) slope {
+	return slope{2*col - 1, 2 * depth}
+}
+
+// Round num/den to nearest integer, rounding halves up
+func roundTiesUp(num int, den int) int {
+	return floorDiv(2*num+den, 2*den)
+}
+
+// Round num/den to nearest integer, rounding halves down
+func roundTiesDown(num int, den int) int {
+	return -floorDiv(-2*num+den, 2*den)
+}
+
+func floorDiv(a int, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,103 @@
+package grid
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+var UNKNOWN_GENERATOR error = errors.New("Unknown generator")
+var NO_GENERATOR_FOR_DEPTH error = errors.New("No generator configured for depth")
+
+// Algorithm for generating levels. The same rng state must always produce the same level.
+// Levels too small or too closed for two staircases are an error.
+type Generator interface {
+	Generate(width int, height int, rng *rand.Rand) (*Grid, *Metadata, error)
+}
+
+// Generator for caverns with rectangular rooms connected by corridors
+type RectangularCavernGenerator struct {
+	MinNodeWidth  int
+	MinNodeHeight int
+}
+
+func (gen RectangularCavernGenerator) Generate(width int, height int, rng *rand.Rand) (*Grid, *Metadata, error) {
+	return newRectangularCavern(width, height, gen.MinNodeWidth, gen.MinNodeHeight, rng)
+}
+
+// Generator for natural looking caverns grown with cellular automata
+type NaturalCavernGenerator struct {
+	EmptySpacePercentage int
+	CleanUpRounds        int
+}
+
+func (gen NaturalCavernGenerator) Generate(width int, height int, rng *rand.Rand) (*Grid, *Metadata, error) {
+	return newNaturalCavern(width, height, gen.EmptySpacePercentage, gen.CleanUpRounds, rng)
+}
+
+var generators = map[string]Generator{}
+
+func init() {
+	RegisterGenerator("rectangular", RectangularCavernGenerator{7, 7})
+	RegisterGenerator("natural", NaturalCavernGenerator{45, 2})
+}
+
+// Register generator with name, replacing any generator previously registered with the same name
+func RegisterGenerator(name string, generator Generator) {
+	generators[name] = generator
+}
+
+// Return generator registered with name
+func GetGenerator(name string) (Generator, error) {
+	if generator, ok := generators[name]; ok {
+		return generator, nil
+	}
+	return nil, UNKNOWN_GENERATOR
+}
+
+// Return names of all registered generators in alphabetical order
+func GeneratorNames() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Names of generators to choose from for levels at Depth and deeper
+type DepthRule struct {
+	Depth      int
+	Generators []string
+}
+
+// Rules for choosing a level generator by dungeon depth. The deepest rule not deeper than the level applies.
+type GeneratorConfiguration []DepthRule
+
+// Every level is equally likely to be a natural or a rectangular cavern
+var DefaultGeneratorConfiguration = GeneratorConfiguration{
+	{1, []string{"natural", "rectangular"}},
+}
+
+// Return a generator for a level at depth, picking randomly if the applicable rule lists several
+func (c GeneratorConfiguration) GeneratorFor(depth int, rng *rand.Rand) (Generator, error) {
+	var rule *DepthRule
+	for i := range c {
+		if c[i].Depth <= depth && (rule == nil || c[i].Depth > rule.Depth) {
+			rule = &c[i]
+		}
+	}
+	if rule == nil || len(rule.Generators) == 0 {
+		return nil, NO_GENERATOR_FOR_DEPTH
+	}
+	return GetGenerator(rule.Generators[rng.Intn(len(rule.Generators))])
+}
+
+// Generate a level for depth with the generator the configuration chooses
+func (c GeneratorConfiguration) Generate(depth int, width int, height int, rng *rand.Rand) (*Grid, *Metadata, error) {
+	generator, err := c.GeneratorFor(depth, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	return generator.Generate(width, height, rng)
+}
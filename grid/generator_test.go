@@ -0,0 +1,82 @@
+package grid
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/mahe-go/grogue/util"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Generated levels are compared to testdata/<name>.golden, run the tests with -update after changing a generator on purpose
+func TestGeneratedLevelsMatchGoldenFiles(t *testing.T) {
+	levels := map[string]func(seed int64) (*Grid, error){
+		"rectangular": func(seed int64) (*Grid, error) { return NewRectangularCavernGrid(80, 24, 7, 7, util.NewRand(seed)) },
+		"natural":     func(seed int64) (*Grid, error) { return NewNaturalCavernGrid(80, 24, 45, 2, util.NewRand(seed)) },
+	}
+	for name, generate := range levels {
+		for _, seed := range []int64{1, 42} {
+			golden := filepath.Join("testdata", fmt.Sprintf("%s-%d.golden", name, seed))
+			g, err := generate(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := g.String()
+			if *update {
+				if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("%s differs, got\n%s", golden, got)
+			}
+		}
+	}
+}
+
+func TestGeneratedMetadataDescribesTheLevel(t *testing.T) {
+	for _, name := range []string{"rectangular", "natural"} {
+		generator, err := GetGenerator(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for seed := int64(0); seed < 50; seed++ {
+			g, m, err := generator.Generate(60, 20, util.NewRand(seed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.StairsUp == m.StairsDown || !g.LabelComponents(CellIsPassable, CardinalDirections).Connected(m.StairsUp, m.StairsDown) {
+				t.Fatalf("%s %d: stairs %v and %v not connected", name, seed, m.StairsUp, m.StairsDown)
+			}
+			for i, room := range m.Rooms {
+				for _, c := range room.Cells {
+					if !g.TestCellAtXY(CellIsTraversable, c.X, c.Y) || !room.Bounds.Contains(c.X, c.Y) {
+						t.Fatalf("%s %d: room %d cell %v is not open floor in its bounds", name, seed, i, c)
+					}
+				}
+				if at, ok := m.RoomAt(room.Centre.X, room.Centre.Y); !ok || at != i {
+					t.Fatalf("%s %d: room %d does not contain its centre", name, seed, i)
+				}
+			}
+			for i, corridor := range m.Corridors {
+				for _, c := range corridor.Cells {
+					if !g.TestCellAtXY(CellIsPassable, c.X, c.Y) {
+						t.Fatalf("%s %d: corridor %d cell %v is not a passage", name, seed, i, c)
+					}
+				}
+				if len(corridor.Rooms) == 0 && len(corridor.Corridors) == 0 {
+					t.Fatalf("%s %d: corridor %d leads nowhere", name, seed, i)
+				}
+			}
+		}
+	}
+}
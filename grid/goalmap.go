@@ -0,0 +1,122 @@
+package grid
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Distance of cells a goal map cannot reach
+const UNREACHABLE = math.MaxInt32
+
+// Distance field flooding outward from goal cells (a.k.a. Dijkstra map).
+// Following the downhill direction from any reachable cell leads to the nearest goal.
+type GoalMap struct {
+	values     []int
+	Width      int
+	Height     int
+	grid       *Grid
+	pathfinder *Pathfinder
+}
+
+// Return a goal map of g, flooded from goals using the pathfinder's directions, walkability and costs
+func (p *Pathfinder) GoalMap(g *Grid, goals ...Point) *GoalMap {
+	m := &GoalMap{make([]int, g.Width*g.Height), g.Width, g.Height, g, p}
+	for i := range m.values {
+		m.values[i] = UNREACHABLE
+	}
+	for _, goal := range goals {
+		if index, err := g.cellIndex(goal.X, goal.Y); err == nil {
+			m.values[index] = 0
+		}
+	}
+	m.relax()
+	return m
+}
+
+// Return distance to nearest goal from (x,y), or UNREACHABLE
+func (m *GoalMap) Distance(x int, y int) int {
+	index, err := m.grid.cellIndex(x, y)
+	if err != nil {
+		return UNREACHABLE
+	}
+	return m.values[index]
+}
+
+// Return the direction to the lowest neighbour of (x,y).
+// Returns false if (x,y) is unreachable or no neighbour is lower.
+func (m *GoalMap) Downhill(x int, y int) (Direction, bool) {
+	best := m.Distance(x, y)
+	if best == UNREACHABLE {
+		return Direction{}, false
+	}
+	var direction Direction
+	found := false
+	for _, d := range m.pathfinder.Directions {
+		if !m.pathfinder.canStep(m.grid, Point{x, y}, d) {
+			continue
+		}
+		if value := m.Distance(x+d.Dx, y+d.Dy); value < best {
+			best = value
+			direction = d
+			found = true
+		}
+	}
+	return direction, found
+}
+
+// Return a new goal map with all reachable distances multiplied by factor
+func (m *GoalMap) Scale(factor float64) *GoalMap {
+	scaled := &GoalMap{make([]int, len(m.values)), m.Width, m.Height, m.grid, m.pathfinder}
+	for i, value := range m.values {
+		if value == UNREACHABLE {
+			scaled.values[i] = UNREACHABLE
+		} else {
+			scaled.values[i] = int(math.Floor(float64(value)*factor + 0.5))
+		}
+	}
+	return scaled
+}
+
+// Return a map for fleeing from the goals. Distances are multiplied by -coefficient
+// and the map is flooded again, so that following it downhill leads away from the goals
+// while still preferring routes that don't end in a dead end. A coefficient of about 1.2 works well.
+func (m *GoalMap) Flee(coefficient float64) *GoalMap {
+	flee := m.Scale(-coefficient)
+	flee.relax()
+	return flee
+}
+
+// Return a LocationPredicate matching cells within distance of a goal
+func (m *GoalMap) Within(distance int) LocationPredicate {
+	return func(g *Grid, x int, y int) bool {
+		return m.Distance(x, y) <= distance
+	}
+}
+
+// Lower every cell to at most the cost of reaching it from a lower neighbour
+func (m *GoalMap) relax() {
+	open := &pathQueue{}
+	for index, value := range m.values {
+		if value != UNREACHABLE {
+			heap.Push(open, pathEntry{Point{index % m.Width, index / m.Width}, index, value})
+		}
+	}
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathEntry)
+		if current.priority > m.values[current.index] {
+			continue
+		}
+		for _, d := range m.pathfinder.Directions {
+			next := current.Step(d)
+			nextIndex, err := m.grid.cellIndex(next.X, next.Y)
+			if err != nil || !m.pathfinder.Walkable(m.grid.cells[nextIndex]) || !m.pathfinder.canStep(m.grid, current.Point, d) {
+				continue
+			}
+			value := current.priority + m.pathfinder.stepCost(m.grid.cells[nextIndex])
+			if value < m.values[nextIndex] {
+				m.values[nextIndex] = value
+				heap.Push(open, pathEntry{next, nextIndex, value})
+			}
+		}
+	}
+}
@@ -21,7 +21,44 @@ var SouthWest = Direction{-1, 1}
 var West = Direction{-1, 0}
 var NorthWest = Direction{-1, -1}
 
+// The four main compass directions
+var CardinalDirections = []Direction{North, East, South, West}
+
+// All eight compass directions, including diagonals
+var AllDirections = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+// Return true if direction is diagonal
+func (d Direction) IsDiagonal() bool {
+	return d.Dx != 0 && d.Dy != 0
+}
+
+// Location on a grid
+type Point struct {
+	X int
+	Y int
+}
+
+// Return the point one step away in direction
+func (p Point) Step(direction Direction) Point {
+	return Point{p.X + direction.Dx, p.Y + direction.Dy}
+}
+
+// Return the direction of the first step from p towards other
+func (p Point) DirectionTo(other Point) Direction {
+	return Direction{sign(other.X - p.X), sign(other.Y - p.Y)}
+}
+
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	} else if x > 0 {
+		return 1
+	}
+	return 0
+}
+
 var GRID_OVERFLOW error = errors.New("Grid overflow")
+var NO_ROOM_FOR_STAIRS error = errors.New("No room for staircases")
 
 type Grid struct {
 	cells  []GridCell
@@ -48,13 +85,13 @@ func NewSolidGridOfType(width int, height int, cellType CellType) *Grid {
 	return grid
 }
 
-//Return a grid with cells of type emptyCellType and solidCellPercentage% cells of type solidCellType at random locations
-func NewRandomGrid(width int, height int, solidCellPercentage int, solidCellType CellType, emptyCellType CellType) *Grid {
+//Return a grid with cells of type emptyCellType and solidCellPercentage% cells of type solidCellType at random locations drawn from rng
+func NewRandomGrid(width int, height int, solidCellPercentage int, solidCellType CellType, emptyCellType CellType, rng *rand.Rand) *Grid {
 	grid := &Grid{make([]GridCell, width*height), width, height}
 
 	for x := 0; x < width; x++ {
 		for y := 0; y < height; y++ {
-			probability := rand.Intn(100)
+			probability := rng.Intn(100)
 			if solidCellPercentage > probability {
 				grid.Set(x, y, NewGridCellOfTypeValue(solidCellType))
 			} else {
@@ -197,21 +234,47 @@ func (g *Grid) ApplyAtXYMAtching(mod Modification, condition LocationPredicate,
 
 //Apply modification to all connected cells matching a condition starting from (x,y), using 4-way floodFill algorithm
 func (g *Grid) ApplyToConnectedCells(mod CellModification, selectCondition CellPredicate, x int, y int) {
-	left := x - 1
-	right := x + 1
-	up := y - 1
-	down := y + 1
-
-	origo, err := g.Get(x, y)
-	if err == nil && selectCondition(origo) {
-		g.ApplyToCellAtXY(mod, x, y)
-		g.ApplyToConnectedCells(mod, selectCondition, left, y)
-		g.ApplyToConnectedCells(mod, selectCondition, right, y)
-		g.ApplyToConnectedCells(mod, selectCondition, x, up)
-		g.ApplyToConnectedCells(mod, selectCondition, x, down)
-	} else {
-		return
+	for _, p := range g.ConnectedCells(selectCondition, CardinalDirections, x, y) {
+		g.ApplyToCellAtXY(mod, p.X, p.Y)
+	}
+}
+
+// Apply modification to all connected cells matching a condition starting from (x,y), also connecting diagonally
+func (g *Grid) ApplyToEightConnectedCells(mod CellModification, selectCondition CellPredicate, x int, y int) {
+	for _, p := range g.ConnectedCells(selectCondition, AllDirections, x, y) {
+		g.ApplyToCellAtXY(mod, p.X, p.Y)
+	}
+}
+
+// Return the cells matching condition connected to (x,y) through directions, nearest first.
+// Returns nothing if (x,y) doesn't match. The fill uses a queue rather than recursion, so any grid size is fine.
+func (g *Grid) ConnectedCells(condition CellPredicate, directions []Direction, x int, y int) []Point {
+	return g.floodFill(condition, directions, x, y, make([]bool, len(g.cells)))
+}
+
+// Breadth first flood fill marking the cells it reaches in visited, which may be shared between fills
+func (g *Grid) floodFill(condition CellPredicate, directions []Direction, x int, y int, visited []bool) []Point {
+	start, err := g.cellIndex(x, y)
+	if err != nil || visited[start] || !condition(g.cells[start]) {
+		return nil
+	}
+	visited[start] = true
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		for _, d := range directions {
+			next, err := g.cellIndex(current%g.Width+d.Dx, current/g.Width+d.Dy)
+			if err == nil && !visited[next] && condition(g.cells[next]) {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	points := make([]Point, len(queue))
+	for i, index := range queue {
+		points[i] = Point{index % g.Width, index / g.Width}
 	}
+	return points
 }
 
 // Surround all empty space with walls.
@@ -232,6 +295,17 @@ func (grid *Grid) buildCavernWalls() {
 // Apply modification to cell matching condition on a straight line from (startX, startY) to (endX, endY).
 // Line is calculated with Bresenham algorithm.
 func (grid *Grid) ApplyOnLine(mod CellModification, cond CellPredicate, startx int, starty int, endx int, endy int) {
+	for _, p := range grid.Line(startx, starty, endx, endy) {
+		if err := grid.ApplyToCellAtXYMatching(mod, cond, p.X, p.Y); err != nil {
+			return
+		}
+	}
+}
+
+// Return the points on a straight line from (startX, startY) to (endX, endY), calculated with Bresenham algorithm.
+// The line ends early where it leaves the grid.
+func (grid *Grid) Line(startx int, starty int, endx int, endy int) []Point {
+	var points []Point
 
 	// Bresenham's line drawing algorithm
 	var cx int = startx
@@ -256,14 +330,11 @@ func (grid *Grid) ApplyOnLine(mod CellModification, cond CellPredicate, startx i
 
 	for {
 		if cy >= grid.Height || cy < 0 || cx >= grid.Width || cx < 0 {
-			return
-		}
-		err := grid.ApplyToCellAtXYMatching(mod, cond, cx, cy)
-		if err != nil {
-			return
+			return points
 		}
+		points = append(points, Point{cx, cy})
 		if (cx == endx) && (cy == endy) {
-			return
+			return points
 		}
 		var e2 int = 2 * e
 		if e2 > (0 - dy) {
@@ -314,13 +385,28 @@ func (g Grid) String() string {
 	return buffer
 }
 
-func (g *Grid) AddStairCases() {
-	var x, y int
-	for x, y = rand.Intn(g.Width), rand.Intn(g.Height); g.TestCellAtXY(CellIsTraversable.Not(), x, y); x, y = rand.Intn(g.Width), rand.Intn(g.Height) {
+// Place staircases up and down at two different traversable cells drawn from rng. Returns their locations,
+// or NO_ROOM_FOR_STAIRS if there are fewer than two such cells.
+func (g *Grid) AddStairCases(rng *rand.Rand) (Point, Point, error) {
+	var candidates []Point
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			if g.TestCellAtXY(CellIsTraversable.And(CellIsDoor.Not()), x, y) {
+				candidates = append(candidates, Point{x, y})
+			}
+		}
 	}
-	g.ApplyToCellAtXY(GridCellTypeConverter(STAIRCASE_UP), x, y)
-
-	for x, y = rand.Intn(g.Width), rand.Intn(g.Height); g.TestCellAtXY(CellIsTraversable.Not(), x, y); x, y = rand.Intn(g.Width), rand.Intn(g.Height) {
+	if len(candidates) < 2 {
+		return Point{}, Point{}, NO_ROOM_FOR_STAIRS
+	}
+	i := rng.Intn(len(candidates))
+	// draw from one candidate less and skip over the staircase up, so that the staircases are apart
+	j := rng.Intn(len(candidates) - 1)
+	if j >= i {
+		j++
 	}
-	g.ApplyToCellAtXY(GridCellTypeConverter(STAIRCASE_DOWN), x, y)
+	up, down := candidates[i], candidates[j]
+	g.ApplyToCellAtXY(GridCellTypeConverter(STAIRCASE_UP), up.X, up.Y)
+	g.ApplyToCellAtXY(GridCellTypeConverter(STAIRCASE_DOWN), down.X, down.Y)
+	return up, down, nil
 }
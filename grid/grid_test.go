@@ -0,0 +1,56 @@
+package grid
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// A flood fill over a large level must not run out of stack
+func TestConnectedCellsOfLargeOpenGrid(t *testing.T) {
+	g := NewSolidGridOfType(500, 500, ROOM)
+	if n := len(g.ConnectedCells(GridCellIsOfType(ROOM), CardinalDirections, 250, 250)); n != 500*500 {
+		t.Errorf("got %d connected cells, want %d", n, 500*500)
+	}
+}
+
+func TestEightConnectedFillCrossesDiagonalGaps(t *testing.T) {
+	g, err := ParseGrid(`..##
+..##
+##..
+##..`, Legend{'.': ROOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.ApplyToConnectedCells(GridCellTypeConverter(CORRIDOR), GridCellIsOfType(ROOM), 0, 0)
+	if g.TestCellAtXY(GridCellIsOfType(CORRIDOR), 3, 3) {
+		t.Error("the fill in four directions crossed the diagonal gap")
+	}
+	g.ApplyToEightConnectedCells(GridCellTypeConverter(SOLID_ROCK), GridCellIsOfType(CORRIDOR).Or(GridCellIsOfType(ROOM)), 0, 0)
+	for _, p := range []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {2, 3}} {
+		if !g.TestCellAtXY(GridCellIsOfType(SOLID_ROCK), p.X, p.Y) {
+			t.Errorf("(%d,%d) was not filled", p.X, p.Y)
+		}
+	}
+}
+
+func TestStairCasesNeedTwoFloorCells(t *testing.T) {
+	g, err := ParseGrid("#.+#", Legend{'.': ROOM, '+': DOOR_CLOSED})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := g.AddStairCases(rand.New(rand.NewSource(1))); err != NO_ROOM_FOR_STAIRS {
+		t.Errorf("got %v, want %v", err, NO_ROOM_FOR_STAIRS)
+	}
+
+	g, err = ParseGrid("#..#", Legend{'.': ROOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	up, down, err := g.AddStairCases(rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up == down || !g.TestCellAtXY(GridCellIsOfType(STAIRCASE_UP), up.X, up.Y) || !g.TestCellAtXY(GridCellIsOfType(STAIRCASE_DOWN), down.X, down.Y) {
+		t.Errorf("staircases at %v and %v\n%s", up, down, g)
+	}
+}
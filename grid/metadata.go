@@ -0,0 +1,202 @@
+package grid
+
+import (
+	"sort"
+
+	"github.com/mahe-go/grogue/util"
+)
+
+// Information about a generated level besides its cells
+type Metadata struct {
+	StairsUp   Point
+	StairsDown Point
+	Rooms      []Room
+	Corridors  []Corridor
+}
+
+// Rectangular area of a grid
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+func newRect(x int, y int, width int, height int) *Rect {
+	return &Rect{x, y, width, height}
+}
+
+// Return true if (x,y) is inside the rectangle
+func (r Rect) Contains(x int, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Open area of a level. Rooms are referred to by their index in Metadata.Rooms.
+type Room struct {
+	Bounds Rect
+	// Cell of the room closest to the middle of its bounds
+	Centre Point
+	Cells  []Point
+	// Corridors leading out of the room
+	Corridors []int
+	// Rooms reachable from this one through corridors without crossing a third room, or touching this one
+	Neighbours []int
+}
+
+// Passage dug between rooms. Corridors are referred to by their index in Metadata.Corridors.
+type Corridor struct {
+	Cells []Point
+	// Rooms the corridor leads into
+	Rooms []int
+	// Other corridors the corridor runs into
+	Corridors []int
+}
+
+// Return a room made of cells, which must not be empty
+func newRoom(cells []Point) Room {
+	min, max := cells[0], cells[0]
+	for _, c := range cells {
+		min = Point{util.Min(min.X, c.X), util.Min(min.Y, c.Y)}
+		max = Point{util.Max(max.X, c.X), util.Max(max.Y, c.Y)}
+	}
+	bounds := Rect{min.X, min.Y, max.X - min.X + 1, max.Y - min.Y + 1}
+	middle := Point{bounds.X + bounds.Width/2, bounds.Y + bounds.Height/2}
+	centre := cells[0]
+	for _, c := range cells {
+		if distanceSquared(c, middle) < distanceSquared(centre, middle) {
+			centre = c
+		}
+	}
+	return Room{Bounds: bounds, Centre: centre, Cells: cells}
+}
+
+func distanceSquared(a Point, b Point) int {
+	return (a.X-b.X)*(a.X-b.X) + (a.Y-b.Y)*(a.Y-b.Y)
+}
+
+// Return the index of the room containing (x,y)
+func (m *Metadata) RoomAt(x int, y int) (int, bool) {
+	for i, room := range m.Rooms {
+		if !room.Bounds.Contains(x, y) {
+			continue
+		}
+		for _, c := range room.Cells {
+			if c.X == x && c.Y == y {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Fill in which rooms and corridors meet, once all of them have been added
+func (m *Metadata) link() {
+	roomOf := m.roomOf()
+	corridorOf := map[Point]int{}
+	for i, corridor := range m.Corridors {
+		for _, c := range corridor.Cells {
+			corridorOf[c] = i
+		}
+	}
+
+	// a corridor leads only where its ends are, running alongside a room or corridor does not enter it
+	for i := range m.Corridors {
+		for _, c := range m.Corridors[i].ends() {
+			for _, d := range AllDirections {
+				next := c.Step(d)
+				if room, ok := roomOf[next]; ok {
+					m.Corridors[i].Rooms = appendUnique(m.Corridors[i].Rooms, room)
+					m.Rooms[room].Corridors = appendUnique(m.Rooms[room].Corridors, i)
+				}
+				if other, ok := corridorOf[next]; ok && other != i {
+					m.Corridors[i].Corridors = appendUnique(m.Corridors[i].Corridors, other)
+					m.Corridors[other].Corridors = appendUnique(m.Corridors[other].Corridors, i)
+				}
+			}
+		}
+	}
+	for i := range m.Corridors {
+		sort.Ints(m.Corridors[i].Rooms)
+		sort.Ints(m.Corridors[i].Corridors)
+	}
+
+	for i := range m.Rooms {
+		room := &m.Rooms[i]
+		sort.Ints(room.Corridors)
+		for _, c := range room.Cells {
+			for _, d := range CardinalDirections {
+				if other, ok := roomOf[c.Step(d)]; ok && other != i {
+					room.Neighbours = appendUnique(room.Neighbours, other)
+				}
+			}
+		}
+		for _, other := range m.roomsThroughCorridors(room.Corridors) {
+			if other != i {
+				room.Neighbours = appendUnique(room.Neighbours, other)
+			}
+		}
+		sort.Ints(room.Neighbours)
+	}
+}
+
+// Return the cells of the corridor next to at most one other of its cells: both ends of a passage,
+// or of every stretch of it when the corridor was dug in pieces
+func (c Corridor) ends() []Point {
+	cells := map[Point]bool{}
+	for _, p := range c.Cells {
+		cells[p] = true
+	}
+	var ends []Point
+	for _, p := range c.Cells {
+		next := 0
+		for _, d := range CardinalDirections {
+			if cells[p.Step(d)] {
+				next++
+			}
+		}
+		if next <= 1 {
+			ends = append(ends, p)
+		}
+	}
+	return ends
+}
+
+// Return the index of the room of every room cell
+func (m *Metadata) roomOf() map[Point]int {
+	roomOf := map[Point]int{}
+	for i, room := range m.Rooms {
+		for _, c := range room.Cells {
+			roomOf[c] = i
+		}
+	}
+	return roomOf
+}
+
+// Return the rooms entered by the corridors and by any corridors connected to them
+func (m *Metadata) roomsThroughCorridors(corridors []int) []int {
+	var rooms []int
+	visited := map[int]bool{}
+	queue := append([]int(nil), corridors...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for _, room := range m.Corridors[current].Rooms {
+			rooms = appendUnique(rooms, room)
+		}
+		queue = append(queue, m.Corridors[current].Corridors...)
+	}
+	return rooms
+}
+
+func appendUnique(list []int, value int) []int {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,55 @@
+package grid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func rectangle(x int, y int, width int, height int) []Point {
+	var cells []Point
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			cells = append(cells, Point{i, j})
+		}
+	}
+	return cells
+}
+
+// Two rooms side by side, and a corridor from the top of the first one running along the second one into a third
+func TestCorridorsLeadOnlyWhereTheyEnd(t *testing.T) {
+	m := &Metadata{
+		Rooms: []Room{newRoom(rectangle(0, 0, 3, 3)), newRoom(rectangle(5, 2, 3, 3)), newRoom(rectangle(10, 0, 3, 3))},
+		Corridors: []Corridor{
+			{Cells: []Point{{3, 1}, {4, 1}, {5, 1}, {6, 1}, {7, 1}, {8, 1}, {9, 1}}},
+		},
+	}
+	m.link()
+
+	if rooms := m.Corridors[0].Rooms; !reflect.DeepEqual(rooms, []int{0, 2}) {
+		t.Errorf("corridor leads into rooms %v, want [0 2]", rooms)
+	}
+	if corridors := m.Rooms[1].Corridors; len(corridors) != 0 {
+		t.Errorf("room 1 has corridors %v, the corridor only runs along it", corridors)
+	}
+	if neighbours := m.Rooms[0].Neighbours; !reflect.DeepEqual(neighbours, []int{2}) {
+		t.Errorf("room 0 has neighbours %v, want [2]", neighbours)
+	}
+}
+
+func TestRoomsSurviveEncoding(t *testing.T) {
+	room := newRoom(append(rectangle(1, 1, 3, 2), Point{4, 2}))
+	room.Corridors = []int{1}
+	room.Neighbours = []int{0, 2}
+	data, err := json.Marshal(&room)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Room
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, room) {
+		t.Errorf("decoded %+v from %s, want %+v", decoded, data, room)
+	}
+}
@@ -0,0 +1,86 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+var UNKNOWN_RUNE error = errors.New("No cell type for rune")
+var AMBIGUOUS_RUNE error = errors.New("Rune stands for several cell types")
+
+// Cell types by the rune standing for them in ASCII art
+type Legend map[rune]CellType
+
+// Error in ASCII art at line and column, both starting from 1
+type ParseError struct {
+	Line   int
+	Column int
+	Rune   rune
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %v: %q", e.Line, e.Column, e.Err, e.Rune)
+}
+
+// Return the cell type r stands for. The legend is checked first, then the runes of CellTypes,
+// which must be unambiguous (ROOM and CORRIDOR are both '.', so one of them needs a legend entry).
+func (l Legend) lookup(r rune) (CellType, error) {
+	if typ, ok := l[r]; ok {
+		return typ, nil
+	}
+	var found []CellType
+	for _, typ := range CellTypes {
+		if typ.Rune == r {
+			found = append(found, typ)
+		}
+	}
+	if len(found) == 0 {
+		return CellType{}, UNKNOWN_RUNE
+	} else if len(found) > 1 {
+		return CellType{}, AMBIGUOUS_RUNE
+	}
+	return found[0], nil
+}
+
+// Build a grid from ASCII art, the inverse of Grid.String. Each line is a row of the grid.
+// Lines shorter than the longest one are padded with SOLID_ROCK, as trailing spaces are easily lost.
+func ParseGrid(text string, legend Legend) (*Grid, error) {
+	text = strings.TrimSuffix(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	rows := make([][]rune, len(lines))
+	width := 0
+	for y, line := range lines {
+		rows[y] = []rune(line)
+		if len(rows[y]) > width {
+			width = len(rows[y])
+		}
+	}
+
+	grid := NewSolidGridOfType(width, len(rows), SOLID_ROCK)
+	for y, row := range rows {
+		for x, r := range row {
+			typ, err := legend.lookup(r)
+			if err != nil {
+				return nil, &ParseError{y + 1, x + 1, r, err}
+			}
+			grid.Set(x, y, NewGridCellOfTypeValue(typ))
+		}
+	}
+	return grid, nil
+}
+
+// Build a grid from ASCII art read from r
+func ReadGrid(r io.Reader, legend Legend) (*Grid, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrid(string(data), legend)
+}
@@ -0,0 +1,150 @@
+package grid
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/mahe-go/grogue/util"
+)
+
+var NO_PATH error = errors.New("No path")
+
+// Function type for function returning the cost of entering a grid cell
+type CellCost func(cell GridCell) int
+
+// Every cell costs the same to enter
+var UniformCost CellCost = func(cell GridCell) int {
+	return 1
+}
+
+// Settings for finding paths on a grid. Squeeze tells whether paths may pass diagonally
+// between two cells that are not walkable.
+type Pathfinder struct {
+	Directions []Direction
+	Walkable   CellPredicate
+	Cost       CellCost
+	Squeeze    bool
+}
+
+// Return a pathfinder moving in directions through cells matching walkable, squeezing through where needed.
+// If cost is nil, UniformCost is used. Costs below 1 are treated as 1.
+func NewPathfinder(directions []Direction, walkable CellPredicate, cost CellCost) *Pathfinder {
+	if cost == nil {
+		cost = UniformCost
+	}
+	return &Pathfinder{directions, walkable, cost, true}
+}
+
+// Return the shortest path from (startX, startY) to (endX, endY) using the A* algorithm.
+// The path excludes the starting point and includes the end point.
+func (p *Pathfinder) AStar(g *Grid, startX int, startY int, endX int, endY int) ([]Point, error) {
+	return p.search(g, Point{startX, startY}, Point{endX, endY}, p.heuristic())
+}
+
+// Return the shortest path from (startX, startY) to (endX, endY) using Dijkstra's algorithm.
+// The path excludes the starting point and includes the end point.
+func (p *Pathfinder) Dijkstra(g *Grid, startX int, startY int, endX int, endY int) ([]Point, error) {
+	return p.search(g, Point{startX, startY}, Point{endX, endY}, func(a Point, b Point) int {
+		return 0
+	})
+}
+
+// Estimate of the cost between two points, never larger than the real cost
+type heuristic func(a Point, b Point) int
+
+func (p *Pathfinder) heuristic() heuristic {
+	for _, d := range p.Directions {
+		if d.IsDiagonal() {
+			return func(a Point, b Point) int {
+				return util.Max(util.Abs(a.X-b.X), util.Abs(a.Y-b.Y))
+			}
+		}
+	}
+	return func(a Point, b Point) int {
+		return util.Abs(a.X-b.X) + util.Abs(a.Y-b.Y)
+	}
+}
+
+func (p *Pathfinder) stepCost(cell GridCell) int {
+	return util.Max(1, p.Cost(cell))
+}
+
+// Return true if the pathfinder may move from from to direction without squeezing where it may not
+func (p *Pathfinder) canStep(g *Grid, from Point, direction Direction) bool {
+	return p.Squeeze || !g.IsSqueeze(from.X, from.Y, direction, p.Walkable.Not())
+}
+
+func (p *Pathfinder) search(g *Grid, start Point, end Point, estimate heuristic) ([]Point, error) {
+	if _, err := g.cellIndex(start.X, start.Y); err != nil {
+		return nil, err
+	}
+	if _, err := g.cellIndex(end.X, end.Y); err != nil {
+		return nil, err
+	}
+	if !g.TestCellAtXY(p.Walkable, end.X, end.Y) {
+		return nil, NO_PATH
+	}
+
+	startIndex, _ := g.cellIndex(start.X, start.Y)
+	endIndex, _ := g.cellIndex(end.X, end.Y)
+
+	costs := make(map[int]int)
+	cameFrom := make(map[int]int)
+	costs[startIndex] = 0
+
+	open := &pathQueue{}
+	heap.Push(open, pathEntry{start, startIndex, estimate(start, end)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathEntry)
+		if current.index == endIndex {
+			return p.reconstruct(g, cameFrom, startIndex, endIndex), nil
+		}
+		for _, d := range p.Directions {
+			next := current.Step(d)
+			nextIndex, err := g.cellIndex(next.X, next.Y)
+			if err != nil || !p.Walkable(g.cells[nextIndex]) || !p.canStep(g, current.Point, d) {
+				continue
+			}
+			cost := costs[current.index] + p.stepCost(g.cells[nextIndex])
+			if previous, seen := costs[nextIndex]; !seen || cost < previous {
+				costs[nextIndex] = cost
+				cameFrom[nextIndex] = current.index
+				heap.Push(open, pathEntry{next, nextIndex, cost + estimate(next, end)})
+			}
+		}
+	}
+	return nil, NO_PATH
+}
+
+func (p *Pathfinder) reconstruct(g *Grid, cameFrom map[int]int, startIndex int, endIndex int) []Point {
+	path := []Point{}
+	for index := endIndex; index != startIndex; index = cameFrom[index] {
+		path = append(path, Point{index % g.Width, index / g.Width})
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type pathEntry struct {
+	Point
+	index    int
+	priority int
+}
+
+// Priority queue of points to visit, lowest priority first
+type pathQueue []pathEntry
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathEntry)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	*q = old[:n-1]
+	return entry
+}
@@ -26,6 +26,13 @@ func (self CellPredicate) Not() CellPredicate {
 	}
 }
 
+// Return a LocationPredicate testing the cell at the location
+func (self CellPredicate) AtXY() LocationPredicate {
+	return func(g *Grid, x int, y int) bool {
+		return g.TestCellAtXY(self, x, y)
+	}
+}
+
 func (self LocationPredicate) Or(cond LocationPredicate) LocationPredicate {
 	return func(g *Grid, x int, y int) bool {
 		return self(g, x, y) || cond(g, x, y)
@@ -56,4 +63,12 @@ var GridCellIsChecked CellPredicate = func(g GridCell) bool {
 
 var CellIsTraversable CellPredicate = func(c GridCell) bool {
 	return c.Type.Traversable
-}
\ No newline at end of file
+}
+
+// Default condition for cells blocking line of sight. Closed and locked doors block it, open doors don't.
+var CellBlocksSight CellPredicate = CellIsTraversable.Not()
+
+var CellIsDoor CellPredicate = GridCellIsOfType(DOOR_OPEN).Or(GridCellIsOfType(DOOR_CLOSED)).Or(GridCellIsOfType(DOOR_LOCKED))
+
+// Cells the player can get through, if need be by opening or unlocking a door
+var CellIsPassable CellPredicate = CellIsTraversable.Or(CellIsDoor)
@@ -0,0 +1,46 @@
+package grid
+
+import "errors"
+
+var UNKNOWN_SQUEEZE_RULE error = errors.New("Unknown squeeze rule")
+
+// Rule for moving diagonally between two cells that both block the way, e.g. through the gap where two walls touch corners
+type SqueezeRule int
+
+const (
+	SQUEEZE_ALLOWED SqueezeRule = iota
+	SQUEEZE_FORBIDDEN
+	SQUEEZE_FORBIDDEN_FOR_LARGE
+)
+
+// Names of the rules, as given on the command line
+var squeezeRuleNames = map[SqueezeRule]string{
+	SQUEEZE_ALLOWED:             "allowed",
+	SQUEEZE_FORBIDDEN:           "forbidden",
+	SQUEEZE_FORBIDDEN_FOR_LARGE: "forbidden-for-large",
+}
+
+func (r SqueezeRule) String() string {
+	return squeezeRuleNames[r]
+}
+
+// Return the rule called name
+func ParseSqueezeRule(name string) (SqueezeRule, error) {
+	for rule, ruleName := range squeezeRuleNames {
+		if ruleName == name {
+			return rule, nil
+		}
+	}
+	return SQUEEZE_ALLOWED, UNKNOWN_SQUEEZE_RULE
+}
+
+// Return true if the rule lets a creature squeeze through, large or not
+func (r SqueezeRule) Allows(large bool) bool {
+	return r == SQUEEZE_ALLOWED || (r == SQUEEZE_FORBIDDEN_FOR_LARGE && !large)
+}
+
+// Return true if moving from (x,y) to direction passes diagonally between two cells matching blocking
+func (g *Grid) IsSqueeze(x int, y int, direction Direction, blocking CellPredicate) bool {
+	return direction.IsDiagonal() &&
+		g.TestCellAtXY(blocking, x+direction.Dx, y) && g.TestCellAtXY(blocking, x, y+direction.Dy)
+}
@@ -1,67 +1,82 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/jroimartin/gocui"
 	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/game"
 	"github.com/mahe-go/grogue/grid"
 	"github.com/mahe-go/grogue/gui"
+	"github.com/mahe-go/grogue/util"
 )
 
 func main() {
+	seedString := flag.String("seed", util.FormatSeed(util.NewSeed()), "seed for generating the dungeon")
+	savePath := flag.String("save", "grogue.save", "file the game is saved to on quit and resumed from on start")
+	width := flag.Int("width", 80, "width of generated levels")
+	height := flag.Int("height", 20, "height of generated levels")
+	monochrome := flag.Bool("mono", os.Getenv("NO_COLOR") != "", "draw without colours")
+	squeezing := flag.String("squeeze", game.DEFAULT_SQUEEZING.String(), "who may move diagonally between two walls in new games: allowed, forbidden or forbidden-for-large")
+	keys := flag.String("keys", "wasd", "key preset ("+strings.Join(gui.PresetNames(), ", ")+") or file of key bindings")
+	flag.Parse()
+	gui.Monochrome = *monochrome
+
+	keymap, err := gui.LoadKeymap(*keys)
+	if err != nil {
+		log.Panicln(err)
+	}
+	squeezeRule, err := grid.ParseSqueezeRule(*squeezing)
+	if err != nil {
+		log.Panicln(err)
+	}
+
+	gm := loadOrCreateGame(*savePath, util.ParseSeed(*seedString), *width, *height, squeezeRule)
+
 	gcui := gocui.NewGui()
 	if err := gcui.Init(); err != nil {
 		log.Panicln(err)
 	}
 	defer gcui.Close()
 
-	currentGrid, player := rectangularGrid(80, 20)
+	gui.Layout(gm, gcui)
 
-	gui.Layout(currentGrid, player, gcui)
-
-	if err := gcui.SetKeybinding("", rune('q'), 0, quit); err != nil {
+	if err := gui.BindKeys(gcui, gm, keymap, *savePath); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := gcui.SetKeybinding("Map", rune('s'), 0, gui.PlayerMovementHandler(currentGrid, player, grid.South)); err != nil {
-		log.Panicln(err)
-	}
-	if err := gcui.SetKeybinding("Map", rune('w'), 0, gui.PlayerMovementHandler(currentGrid, player, grid.North)); err != nil {
-		log.Panicln(err)
-	}
-	if err := gcui.SetKeybinding("Map", rune('d'), 0, gui.PlayerMovementHandler(currentGrid, player, grid.East)); err != nil {
+	if err := gcui.MainLoop(); err != nil && err != gocui.ErrQuit {
 		log.Panicln(err)
 	}
-	if err := gcui.SetKeybinding("Map", rune('a'), 0, gui.PlayerMovementHandler(currentGrid, player, grid.West)); err != nil {
-		log.Panicln(err)
+}
+
+// Resume the game saved at path, or start a new one of width x height levels from seed with squeezeRule if there is no save.
+// A save that can't be resumed, e.g. one written by another version of the game, is moved aside and a new game is started.
+func loadOrCreateGame(path string, seed int64, width int, height int, squeezeRule grid.SqueezeRule) *game.Game {
+	gm, err := game.LoadFile(path)
+	if err == nil {
+		return gm
 	}
-	if err := gcui.SetKeybinding("Map", rune('<'), 0, gui.StaircaseUpHandler(currentGrid, player)); err != nil {
-		log.Panicln(err)
+	var notice string
+	if !os.IsNotExist(err) {
+		aside := path + ".rejected"
+		if renameErr := os.Rename(path, aside); renameErr != nil {
+			log.Panicln(err, renameErr)
+		}
+		notice = fmt.Sprintf("Your saved game could not be resumed (%v) and was moved to %s.", err, aside)
 	}
-	if err := gcui.SetKeybinding("Map", rune('>'), 0, gui.StaircaseDownHandler(currentGrid, player)); err != nil {
+	species := creature.HUMAN
+	gm, err = game.NewGame(width, height, seed, grid.DefaultGeneratorConfiguration, creature.NewPlayer("Mahe", &species))
+	if err != nil {
 		log.Panicln(err)
 	}
-
-	if err := gcui.MainLoop(); err != nil && err != gocui.ErrQuit {
-		log.Panicln(err)
+	gm.Squeezing = squeezeRule
+	if notice != "" {
+		gm.Log.Add(notice, game.WARNING, gm.Scheduler.Turn())
 	}
-}
-
-func quit(g *gocui.Gui, v *gocui.View) error {
-	return gocui.ErrQuit
-}
-
-func rectangularGrid(width int, height int) (*grid.Grid, *creature.Player) {
-	g := grid.NewRectangularCavernGrid(width, height, 7, 7)
-	p := creature.NewPlayer("Mahe", creature.NewSpecies(1, '@'))
-	creature.PlacePlayerToGridAtMatching(p, g, grid.GridCellIsOfType(grid.STAIRCASE_UP))
-	return g, p
-}
-
-func naturalGrid(width int, height int) (*grid.Grid, *creature.Player) {
-	g := grid.NewNaturalCavernGrid(width, height, 45, 2)
-	p := creature.NewPlayer("Mahe", creature.NewSpecies(1, '@'))
-	creature.PlacePlayerToGridAtMatching(p, g, grid.GridCellIsOfType(grid.STAIRCASE_UP))
-	return g, p
+	return gm
 }
@@ -0,0 +1,32 @@
+package gui
+
+import (
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/util"
+)
+
+// Window of the grid shown in the map view. X and Y are the grid coordinates of its top left corner.
+type Viewport struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Return a viewport of width x height centered on focus. Near the edges of a grid of gridWidth x gridHeight
+// the viewport stops at the edge instead, and a grid smaller than the viewport is shown whole.
+func viewportAround(focus grid.Point, gridWidth int, gridHeight int, width int, height int) Viewport {
+	width = util.Min(width, gridWidth)
+	height = util.Min(height, gridHeight)
+	return Viewport{
+		X:      util.Max(0, util.Min(focus.X-width/2, gridWidth-width)),
+		Y:      util.Max(0, util.Min(focus.Y-height/2, gridHeight-height)),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Return whether grid coordinates (x,y) are inside the viewport
+func (vp Viewport) Contains(x int, y int) bool {
+	return x >= vp.X && x < vp.X+vp.Width && y >= vp.Y && y < vp.Y+vp.Height
+}
@@ -0,0 +1,42 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+// Draw everything in the terminal's own colours, for terminals without colour support.
+// The map still uses bold text, which works without colour, to tell cells in sight from remembered ones.
+var Monochrome = false
+
+// Return the ANSI escape switching to foreground and background, or nothing in monochrome mode.
+// DEFAULT colours are reset to the terminal's own.
+func colourEscape(foreground grid.Colour, background grid.Colour) string {
+	if Monochrome {
+		return ""
+	}
+	escape := "\x1b[0"
+	if foreground != grid.DEFAULT {
+		escape += fmt.Sprintf(";%d", 30+int(foreground-grid.BLACK))
+	}
+	if background != grid.DEFAULT {
+		escape += fmt.Sprintf(";%d", 40+int(background-grid.BLACK))
+	}
+	return escape + "m"
+}
+
+// Return the escape switching to the way gl is drawn. Remembered glyphs are drawn in REMEMBERED_COLOUR.
+// In monochrome mode glyphs in sight are drawn bold instead and remembered ones plain, so that they can still be told apart.
+func glyphEscape(gl glyph) string {
+	switch {
+	case Monochrome && (gl.Remembered || gl == blank):
+		return "\x1b[0m"
+	case Monochrome:
+		return "\x1b[0;1m"
+	case gl.Remembered:
+		return colourEscape(REMEMBERED_COLOUR, grid.DEFAULT)
+	default:
+		return colourEscape(gl.Foreground, gl.Background)
+	}
+}
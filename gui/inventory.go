@@ -0,0 +1,140 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/item"
+)
+
+// Letters inventory items and equipment slots are selected with
+const INVENTORY_LETTERS = "abcdefghijklmnopqrstuvwxyz"
+
+func PickUpHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if err := gm.PickUp(); err == nil {
+			Layout(gm, gcui)
+		}
+		return nil
+	}
+}
+
+// Show the inventory and equipment over the map. Escape closes it.
+func InventoryHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		lines := letterLines(gm.Player.Inventory.List())
+		if len(lines) == 0 {
+			lines = []string{"You carry nothing."}
+		}
+		lines = append(lines, "", "Equipment:")
+		for _, line := range equipmentLines(gm) {
+			lines = append(lines, "  "+line)
+		}
+		return showList(gcui, "Inventory", "Inventory", lines)
+	}
+}
+
+// Ask which item to drop. Pressing the letter of an item drops it, escape cancels.
+func DropHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		return showList(gcui, "Drop", "Drop which item?", letterLines(gm.Player.Inventory.List()))
+	}
+}
+
+// Drop item at index of inventory, bound to the letters of the "Drop" view
+func DropItemHandler(gm *game.Game, index int) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		gm.Drop(index)
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Ask which item to wield or wear. Pressing the letter of an item equips it, escape cancels.
+func EquipHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		return showList(gcui, "Equip", "Wield or wear which item?", letterLines(gm.Player.Inventory.List()))
+	}
+}
+
+// Equip item at index of inventory, bound to the letters of the "Equip" view
+func EquipItemHandler(gm *game.Game, index int) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		gm.Equip(index)
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Ask which slot to empty. Pressing the letter of a slot takes its item off, escape cancels.
+func UnequipHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		return showList(gcui, "Unequip", "Take off which item?", letterLines(equipmentLines(gm)))
+	}
+}
+
+// Take off item in slot at index of item.Slots, bound to the letters of the "Unequip" view
+func UnequipSlotHandler(gm *game.Game, index int) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if index < len(item.Slots) {
+			gm.Unequip(item.Slots[index])
+		}
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Close the view and return to the map
+func CloseViewHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Return one line per slot, describing what is equipped there
+func equipmentLines(gm *game.Game) []string {
+	lines := make([]string, len(item.Slots))
+	for i, slot := range item.Slots {
+		if equipped := gm.Player.Equipment[slot]; equipped != nil {
+			lines[i] = fmt.Sprintf("%-6s %s", slot.String()+":", equipped.Describe())
+		} else {
+			lines[i] = fmt.Sprintf("%-6s -", slot.String()+":")
+		}
+	}
+	return lines
+}
+
+// Prefix lines with the letters they are selected with
+func letterLines(lines []string) []string {
+	lettered := make([]string, len(lines))
+	for i, line := range lines {
+		lettered[i] = fmt.Sprintf("%c - %s", INVENTORY_LETTERS[i], line)
+	}
+	return lettered
+}
+
+// Show lines in a view over the map and make it current
+func showList(gcui *gocui.Gui, name string, title string, lines []string) error {
+	width := len(title) + 2
+	for _, line := range lines {
+		if len(line)+1 > width {
+			width = len(line) + 1
+		}
+	}
+	height := len(lines)
+	if height == 0 {
+		height = 1
+	}
+	v, err := gcui.SetView(name, 2, 1, 2+width+1, 1+height+1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Title = title
+	v.Clear()
+	for _, line := range lines {
+		fmt.Fprintln(v, line)
+	}
+	return gcui.SetCurrentView(name)
+}
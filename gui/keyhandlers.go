@@ -1,53 +1,49 @@
 package gui
 
 import (
-	"math/rand"
+	"os"
 
 	"github.com/jroimartin/gocui"
-	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/game"
 	"github.com/mahe-go/grogue/grid"
 )
 
-func PlayerMovementHandler(g *grid.Grid, player *creature.Player, direction grid.Direction) gocui.KeybindingHandler {
+func PlayerMovementHandler(gm *game.Game, direction grid.Direction) gocui.KeybindingHandler {
 	return func(gcui *gocui.Gui, v *gocui.View) error {
-		player.Move(g, direction)
-		Layout(g, player, gcui)
+		gm.MovePlayer(direction)
+		Layout(gm, gcui)
 		return nil
 	}
 }
 
-func StaircaseUpHandler(g *grid.Grid, player *creature.Player) gocui.KeybindingHandler {
+func StaircaseUpHandler(gm *game.Game) gocui.KeybindingHandler {
 	return func(gcui *gocui.Gui, v *gocui.View) error {
-		if g.TestCellAtXY(grid.GridCellIsOfType(grid.STAIRCASE_UP), player.X, player.Y) {
-			if rand.Intn(2) == 0 {
-				*g = *grid.NewNaturalCavernGrid(g.Width, g.Height, 45, 2)
-			} else {
-				*g = *grid.NewRectangularCavernGrid(g.Width, g.Height, 7, 7)
-			}
+		if err := gm.Ascend(); err == nil {
+			Layout(gm, gcui)
+		}
+		return nil
+	}
+}
 
-			creature.PlacePlayerToGridAtMatching(player, g, grid.GridCellIsOfType(grid.STAIRCASE_DOWN))
-			Layout(g, player, gcui)
-			return nil
-		} else {
-			return nil
+func StaircaseDownHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if err := gm.Descend(); err == nil {
+			Layout(gm, gcui)
 		}
+		return nil
 	}
 }
 
-func StaircaseDownHandler(g *grid.Grid, player *creature.Player) gocui.KeybindingHandler {
+// Save game to path and quit. If the player is dead, the save is removed instead.
+func SaveAndQuitHandler(gm *game.Game, path string) gocui.KeybindingHandler {
 	return func(gcui *gocui.Gui, v *gocui.View) error {
-		if g.TestCellAtXY(grid.GridCellIsOfType(grid.STAIRCASE_DOWN), player.X, player.Y) {
-			if rand.Intn(2) == 0 {
-				*g = *grid.NewNaturalCavernGrid(g.Width, g.Height, 45, 2)
-			} else {
-				*g = *grid.NewRectangularCavernGrid(g.Width, g.Height, 7, 7)
+		if gm.IsOver() {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
 			}
-
-			creature.PlacePlayerToGridAtMatching(player, g, grid.GridCellIsOfType(grid.STAIRCASE_UP))
-			Layout(g, player, gcui)
-			return nil
-		} else {
-			return nil
+		} else if err := gm.SaveFile(path); err != nil {
+			return err
 		}
+		return gocui.ErrQuit
 	}
 }
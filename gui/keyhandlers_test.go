@@ -0,0 +1,23 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+)
+
+func TestDeadPlayerTakingStairsDownDoesNotStopTheGame(t *testing.T) {
+	species := creature.HUMAN
+	gm, err := game.NewGame(60, 20, 42, grid.DefaultGeneratorConfiguration, creature.NewPlayer("Mahe", &species))
+	if err != nil {
+		t.Fatal(err)
+	}
+	down := gm.Level().Metadata.StairsDown
+	gm.Player.SetLocation(down.X, down.Y)
+	gm.Player.HitPoints = 0
+	if err := StaircaseDownHandler(gm)(nil, nil); err != nil {
+		t.Errorf("the handler stopped the main loop with %v", err)
+	}
+}
@@ -0,0 +1,348 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+)
+
+var UNKNOWN_ACTION error = errors.New("Unknown action")
+var UNKNOWN_KEY error = errors.New("Unknown key")
+var UNKNOWN_PRESET error = errors.New("Unknown key preset")
+
+// Something the player does by pressing a key on the map
+type Action string
+
+const (
+	MOVE_NORTH      Action = "move-north"
+	MOVE_NORTH_EAST Action = "move-north-east"
+	MOVE_EAST       Action = "move-east"
+	MOVE_SOUTH_EAST Action = "move-south-east"
+	MOVE_SOUTH      Action = "move-south"
+	MOVE_SOUTH_WEST Action = "move-south-west"
+	MOVE_WEST       Action = "move-west"
+	MOVE_NORTH_WEST Action = "move-north-west"
+	ASCEND          Action = "ascend"
+	DESCEND         Action = "descend"
+	PICK_UP         Action = "pick-up"
+	INVENTORY       Action = "inventory"
+	DROP            Action = "drop"
+	EQUIP           Action = "equip"
+	UNEQUIP         Action = "unequip"
+	OPEN            Action = "open"
+	CLOSE           Action = "close"
+	UNLOCK          Action = "unlock"
+	MESSAGES        Action = "messages"
+	LOOK            Action = "look"
+	HELP            Action = "help"
+	QUIT            Action = "quit"
+)
+
+// All actions, in the order the help screen lists them
+var Actions = []Action{
+	MOVE_NORTH, MOVE_NORTH_EAST, MOVE_EAST, MOVE_SOUTH_EAST, MOVE_SOUTH, MOVE_SOUTH_WEST, MOVE_WEST, MOVE_NORTH_WEST,
+	ASCEND, DESCEND, PICK_UP, INVENTORY, DROP, EQUIP, UNEQUIP, OPEN, CLOSE, UNLOCK, MESSAGES, LOOK, HELP, QUIT,
+}
+
+// Directions of the movement actions
+var actionDirections = map[Action]grid.Direction{
+	MOVE_NORTH:      grid.North,
+	MOVE_NORTH_EAST: grid.NorthEast,
+	MOVE_EAST:       grid.East,
+	MOVE_SOUTH_EAST: grid.SouthEast,
+	MOVE_SOUTH:      grid.South,
+	MOVE_SOUTH_WEST: grid.SouthWest,
+	MOVE_WEST:       grid.West,
+	MOVE_NORTH_WEST: grid.NorthWest,
+}
+
+// Keys that are written by name in keymaps. Any other key is written as the single character it types.
+var keyNames = map[string]gocui.Key{
+	"up":    gocui.KeyArrowUp,
+	"down":  gocui.KeyArrowDown,
+	"left":  gocui.KeyArrowLeft,
+	"right": gocui.KeyArrowRight,
+	"home":  gocui.KeyHome,
+	"end":   gocui.KeyEnd,
+	"pgup":  gocui.KeyPgup,
+	"pgdn":  gocui.KeyPgdn,
+	"enter": gocui.KeyEnter,
+	"space": gocui.KeySpace,
+	"tab":   gocui.KeyTab,
+}
+
+// Return the gocui key written as s in a keymap
+func parseKey(s string) (interface{}, error) {
+	if key, ok := keyNames[s]; ok {
+		return key, nil
+	}
+	if runes := []rune(s); len(runes) == 1 {
+		return runes[0], nil
+	}
+	return nil, UNKNOWN_KEY
+}
+
+// Keys bound to each action
+type Keymap map[Action][]string
+
+// Bind keys to action, replacing the keys it had and unbinding them from any other action
+func (k Keymap) Bind(action Action, keys ...string) {
+	for other, bound := range k {
+		var kept []string
+		for _, key := range bound {
+			if !contains(keys, key) {
+				kept = append(kept, key)
+			}
+		}
+		k[other] = kept
+	}
+	k[action] = keys
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (k Keymap) copy() Keymap {
+	c := Keymap{}
+	for action, keys := range k {
+		c[action] = append([]string(nil), keys...)
+	}
+	return c
+}
+
+// Return a keymap with moves and the keys every preset shares
+func withCommonKeys(moves Keymap) Keymap {
+	k := Keymap{
+		ASCEND:    {"<"},
+		DESCEND:   {">"},
+		PICK_UP:   {"g"},
+		INVENTORY: {"i"},
+		DROP:      {"x"},
+		EQUIP:     {"e"},
+		UNEQUIP:   {"r"},
+		OPEN:      {"o"},
+		CLOSE:     {"c"},
+		UNLOCK:    {"p"},
+		MESSAGES:  {"m"},
+		LOOK:      {";"},
+		HELP:      {"?"},
+		QUIT:      {"q"},
+	}
+	for action, keys := range moves {
+		k[action] = keys
+	}
+	return k
+}
+
+// Keymaps shipped with the game. All of them also move with the arrow keys.
+var Presets = map[string]Keymap{
+	"wasd": withCommonKeys(Keymap{
+		MOVE_NORTH: {"w", "up"},
+		MOVE_EAST:  {"d", "right"},
+		MOVE_SOUTH: {"s", "down"},
+		MOVE_WEST:  {"a", "left"},
+	}),
+	"vi": withCommonKeys(Keymap{
+		MOVE_NORTH:      {"k", "up"},
+		MOVE_NORTH_EAST: {"u"},
+		MOVE_EAST:       {"l", "right"},
+		MOVE_SOUTH_EAST: {"n"},
+		MOVE_SOUTH:      {"j", "down"},
+		MOVE_SOUTH_WEST: {"b"},
+		MOVE_WEST:       {"h", "left"},
+		MOVE_NORTH_WEST: {"y"},
+	}),
+	// Keypad keys type digits with num lock on and act as arrows, home, end and page keys with it off
+	"numpad": withCommonKeys(Keymap{
+		MOVE_NORTH:      {"8", "up"},
+		MOVE_NORTH_EAST: {"9", "pgup"},
+		MOVE_EAST:       {"6", "right"},
+		MOVE_SOUTH_EAST: {"3", "pgdn"},
+		MOVE_SOUTH:      {"2", "down"},
+		MOVE_SOUTH_WEST: {"1", "end"},
+		MOVE_WEST:       {"4", "left"},
+		MOVE_NORTH_WEST: {"7", "home"},
+	}),
+}
+
+// Return names of all presets in alphabetical order
+func PresetNames() []string {
+	names := make([]string, 0, len(Presets))
+	for name := range Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Error in a keymap file at line, starting from 1
+type KeymapError struct {
+	Line int
+	Word string
+	Err  error
+}
+
+func (e *KeymapError) Error() string {
+	return fmt.Sprintf("line %d: %v: %q", e.Line, e.Err, e.Word)
+}
+
+// Build a keymap from text. Each line binds an action to keys separated by spaces, e.g. "look x",
+// replacing the keys the action had. A line "preset vi" starts over from a preset, wasd is used otherwise.
+// Empty lines and lines starting with # are ignored.
+func ParseKeymap(text string) (Keymap, error) {
+	k := Presets["wasd"].copy()
+	for i, line := range strings.Split(text, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+			continue
+		}
+		if words[0] == "preset" {
+			if len(words) != 2 || Presets[words[1]] == nil {
+				return nil, &KeymapError{i + 1, strings.Join(words[1:], " "), UNKNOWN_PRESET}
+			}
+			k = Presets[words[1]].copy()
+			continue
+		}
+		action := Action(words[0])
+		if !isAction(action) {
+			return nil, &KeymapError{i + 1, words[0], UNKNOWN_ACTION}
+		}
+		for _, key := range words[1:] {
+			if _, err := parseKey(key); err != nil {
+				return nil, &KeymapError{i + 1, key, err}
+			}
+		}
+		k.Bind(action, words[1:]...)
+	}
+	return k, nil
+}
+
+func isAction(action Action) bool {
+	for _, a := range Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Build a keymap from text read from r
+func ReadKeymap(r io.Reader) (Keymap, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeymap(string(data))
+}
+
+// Return the preset called name, or if there is no such preset, the keymap in the file at path name
+func LoadKeymap(name string) (Keymap, error) {
+	if preset, ok := Presets[name]; ok {
+		return preset.copy(), nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadKeymap(f)
+}
+
+// Return handlers of all actions
+func actionHandlers(gm *game.Game, keymap Keymap, savePath string) map[Action]gocui.KeybindingHandler {
+	handlers := map[Action]gocui.KeybindingHandler{
+		ASCEND:    unlessModal(StaircaseUpHandler(gm)),
+		DESCEND:   unlessModal(StaircaseDownHandler(gm)),
+		PICK_UP:   unlessModal(PickUpHandler(gm)),
+		INVENTORY: unlessModal(InventoryHandler(gm)),
+		DROP:      unlessModal(DropHandler(gm)),
+		EQUIP:     unlessModal(EquipHandler(gm)),
+		UNEQUIP:   unlessModal(UnequipHandler(gm)),
+		OPEN:      unlessModal(DirectionPromptHandler(gm, "Open which door?", gm.OpenDoor)),
+		CLOSE:     unlessModal(DirectionPromptHandler(gm, "Close which door?", gm.CloseDoor)),
+		UNLOCK:    unlessModal(DirectionPromptHandler(gm, "Pick the lock of which door?", gm.UnlockDoor)),
+		MESSAGES:  unlessModal(MessageHistoryHandler(gm)),
+		LOOK:      LookHandler(gm),
+		HELP:      HelpHandler(keymap),
+		QUIT:      SaveAndQuitHandler(gm, savePath),
+	}
+	for action, direction := range actionDirections {
+		handlers[action] = MoveOrLookHandler(gm, direction)
+	}
+	return handlers
+}
+
+// Bind the keys of keymap to the "Map" view, and the fixed keys of the views shown over it
+func BindKeys(gcui *gocui.Gui, gm *game.Game, keymap Keymap, savePath string) error {
+	handlers := actionHandlers(gm, keymap, savePath)
+	for _, action := range Actions {
+		for _, name := range keymap[action] {
+			key, err := parseKey(name)
+			if err != nil {
+				return err
+			}
+			if err := gcui.SetKeybinding("Map", key, 0, handlers[action]); err != nil {
+				return err
+			}
+		}
+	}
+	if err := gcui.SetKeybinding("Map", gocui.KeyEsc, 0, CancelHandler(gm)); err != nil {
+		return err
+	}
+	if err := gcui.SetKeybinding("History", gocui.KeyArrowUp, 0, ScrollHandler(-1)); err != nil {
+		return err
+	}
+	if err := gcui.SetKeybinding("History", gocui.KeyArrowDown, 0, ScrollHandler(1)); err != nil {
+		return err
+	}
+	for _, view := range []string{"Inventory", "Drop", "Equip", "Unequip", "History", "Help"} {
+		if err := gcui.SetKeybinding(view, gocui.KeyEsc, 0, CloseViewHandler(gm)); err != nil {
+			return err
+		}
+	}
+	for i, letter := range INVENTORY_LETTERS {
+		if err := gcui.SetKeybinding("Drop", letter, 0, DropItemHandler(gm, i)); err != nil {
+			return err
+		}
+		if err := gcui.SetKeybinding("Equip", letter, 0, EquipItemHandler(gm, i)); err != nil {
+			return err
+		}
+		if err := gcui.SetKeybinding("Unequip", letter, 0, UnequipSlotHandler(gm, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Show the keys of every action over the map. Escape closes it.
+func HelpHandler(keymap Keymap) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		return showList(gcui, "Help", "Keys", helpLines(keymap))
+	}
+}
+
+func helpLines(keymap Keymap) []string {
+	lines := make([]string, 0, len(Actions)+2)
+	for _, action := range Actions {
+		keys := "-"
+		if len(keymap[action]) > 0 {
+			keys = strings.Join(keymap[action], " ")
+		}
+		lines = append(lines, fmt.Sprintf("%-16s %s", action, keys))
+	}
+	return append(lines, "", fmt.Sprintf("%-16s %s", "cancel", "esc"))
+}
@@ -4,26 +4,81 @@ import (
 	"fmt"
 
 	"github.com/jroimartin/gocui"
-	"github.com/mahe-go/grogue/creature"
+	"github.com/mahe-go/grogue/game"
 	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/util"
 )
 
-func Layout(g *grid.Grid, player *creature.Player, gui *gocui.Gui) {
+// Rows below the map taken by the status bar and the message log, including their frames
+const PANEL_ROWS = 3 + MESSAGE_LINES + 2
+
+// Lay out the map, status bar and message log. The map view shrinks to fit the terminal and
+// scrolls to follow the player, so levels larger than the terminal can be played.
+// In look mode it follows the look cursor instead, and the message log gives way to a description of the cell under it.
+func Layout(gm *game.Game, gui *gocui.Gui) {
 	gui.SetLayout(func(gui *gocui.Gui) error {
-		if mapView, err := gui.SetView("Map", 0, 0, g.Width+1, g.Height+1); err != nil {
+		g := gm.Level().Grid
+		maxX, maxY := gui.Size()
+		focus := grid.Point{X: gm.Player.X, Y: gm.Player.Y}
+		if lookCursor != nil {
+			focus = *lookCursor
+		}
+		vp := viewportAround(focus, g.Width, g.Height, util.Max(1, maxX-2), util.Max(1, maxY-PANEL_ROWS-2))
+		mapView, err := gui.SetView("Map", 0, 0, vp.Width+1, vp.Height+1)
+		if err != nil {
 			if err != gocui.ErrUnknownView {
 				return err
 			}
-			mapView.Clear()
-			_, err = fmt.Fprint(mapView, *g)
-			if err != nil {
+			if err := gui.SetCurrentView("Map"); err != nil {
+				return err
+			}
+		}
+		// redrawn on every layout, as a resized terminal shows a different window of the grid
+		mapView.Clear()
+		if err := printGlyphs(mapView, renderMap(gm, vp)); err != nil {
+			return err
+		}
+		gui.Cursor = lookCursor != nil
+		if err := mapView.SetCursor(focus.X-vp.X, focus.Y-vp.Y); err != nil {
+			return err
+		}
+		if err := layoutStatus(gm, gui, 0, vp.Height+2, vp.Width+1); err != nil {
+			return err
+		}
+		if pending != nil {
+			if err := layoutPrompt(gui, 0, vp.Height+5, vp.Width+1); err != nil {
+				return err
+			}
+		} else if lookCursor != nil {
+			if err := layoutLook(gm, gui, 0, vp.Height+5, vp.Width+1); err != nil {
+				return err
+			}
+		} else if err := layoutMessages(gm, gui, 0, vp.Height+5, vp.Width+1); err != nil {
+			return err
+		}
+		if gm.IsOver() {
+			if err := layoutSummary(gm, gui); err != nil {
 				return err
 			}
-			mapView.Overwrite = true
-			mapView.SetCursor(player.X, player.Y)
-			mapView.EditWrite(player.Rune)
-			mapView.Overwrite = false
 		}
-		return gui.SetCurrentView("Map")
+		return nil
 	})
 }
+
+// Show the summary of a finished run over the map
+func layoutSummary(gm *game.Game, gui *gocui.Gui) error {
+	maxX, maxY := gui.Size()
+	summary := gm.Summary()
+	width := len(summary) + 2
+	x := (maxX - width) / 2
+	y := maxY/2 - 2
+	if summaryView, err := gui.SetView("Summary", x, y, x+width, y+3); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		summaryView.Title = "Game over"
+		fmt.Fprintln(summaryView, summary)
+		fmt.Fprint(summaryView, "Press q to quit")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/util"
+)
+
+// Cell under the look cursor, or nil when not in look mode
+var lookCursor *grid.Point
+
+// Enter look mode with the cursor on the player, or leave it if already looking
+func LookHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if pending != nil {
+			return nil
+		}
+		if lookCursor == nil {
+			lookCursor = &grid.Point{X: gm.Player.X, Y: gm.Player.Y}
+		} else {
+			lookCursor = nil
+		}
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Leave look mode, or cancel the direction prompt
+func CancelHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		lookCursor = nil
+		pending = nil
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Answer the direction prompt if one is pending, move the look cursor to direction in look mode,
+// otherwise move the player
+func MoveOrLookHandler(gm *game.Game, direction grid.Direction) gocui.KeybindingHandler {
+	move := PlayerMovementHandler(gm, direction)
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if pending != nil {
+			return answerPrompt(gm, gcui, direction)
+		}
+		if lookCursor == nil {
+			return move(gcui, v)
+		}
+		g := gm.Level().Grid
+		lookCursor.X = util.Max(0, util.Min(lookCursor.X+direction.Dx, g.Width-1))
+		lookCursor.Y = util.Max(0, util.Min(lookCursor.Y+direction.Dy, g.Height-1))
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Ignore the key in look mode and while asking for a direction, where only movement keys make sense
+func unlessModal(handler gocui.KeybindingHandler) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		if lookCursor != nil || pending != nil {
+			return nil
+		}
+		return handler(gcui, v)
+	}
+}
+
+// Describe the cell under the look cursor in a view of width starting from (x,y)
+func layoutLook(gm *game.Game, gui *gocui.Gui, x int, y int, width int) error {
+	if lookView, err := gui.SetView("Look", x, y, x+width, y+MESSAGE_LINES+1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		lookView.Title = "Look"
+		lookView.Wrap = true
+		for _, line := range lookLines(gm, lookCursor.X, lookCursor.Y) {
+			fmt.Fprintln(lookView, line)
+		}
+	}
+	return nil
+}
+
+// Return what the player knows about the cell at (x,y). Cells out of sight are described as the player last saw them,
+// and creatures are only known while the cell is visible.
+func lookLines(gm *game.Game, x int, y int) []string {
+	level := gm.Level()
+	if !level.Explored.IsVisible(x, y) {
+		return []string{"You have not explored this place."}
+	}
+	visible := gm.FieldOfView().IsVisible(x, y)
+	terrain, items, seen := level.Memory.Terrain, level.Memory.Items, "remembered"
+	if visible {
+		terrain, items, seen = level.Grid, level.Items, "visible"
+	}
+	cell, err := terrain.Get(x, y)
+	if err != nil {
+		return []string{"You have not explored this place."}
+	}
+	lines := []string{fmt.Sprintf("Terrain: %s (%s)", cell.Type.Description, seen)}
+	if visible {
+		if gm.Player.X == x && gm.Player.Y == y {
+			lines = append(lines, "Creature: "+gm.Player.Describe()+", yourself")
+		}
+		for _, m := range level.Monsters {
+			if m.X != x || m.Y != y {
+				continue
+			}
+			if m.IsAlive() {
+				lines = append(lines, "Creature: "+m.Describe())
+			} else {
+				lines = append(lines, "Corpse: "+m.Describe())
+			}
+		}
+	}
+	for _, it := range items.At(x, y) {
+		lines = append(lines, "Item: "+it.Describe())
+	}
+	return lines
+}
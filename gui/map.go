@@ -0,0 +1,98 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+	"github.com/mahe-go/grogue/item"
+)
+
+// Colour of cells the player remembers but does not currently see
+const REMEMBERED_COLOUR = grid.BLUE
+
+// One cell of the map as drawn on screen. Remembered glyphs are drawn dimmed whatever their colours.
+type glyph struct {
+	Rune       rune
+	Foreground grid.Colour
+	Background grid.Colour
+	Remembered bool
+}
+
+var blank = glyph{' ', grid.DEFAULT, grid.DEFAULT, false}
+
+// Return the part of the map inside vp as the player perceives it. Visible cells are drawn in their own colours,
+// explored cells dimmed as the player last saw them, items included, and unexplored cells left blank.
+// Monsters are only shown when visible.
+func renderMap(gm *game.Game, vp Viewport) [][]glyph {
+	level := gm.Level()
+	visible := gm.FieldOfView()
+
+	rows := make([][]glyph, vp.Height)
+	for row := range rows {
+		rows[row] = make([]glyph, vp.Width)
+		for column := range rows[row] {
+			x, y := vp.X+column, vp.Y+row
+			rows[row][column] = blank
+			if !level.Explored.IsVisible(x, y) {
+				continue
+			}
+			if visible.IsVisible(x, y) {
+				rows[row][column] = cellGlyph(level.Grid, level.Items, x, y)
+			} else {
+				remembered := cellGlyph(level.Memory.Terrain, level.Memory.Items, x, y)
+				remembered.Remembered = true
+				rows[row][column] = remembered
+			}
+		}
+	}
+	put := func(x int, y int, gl glyph) {
+		if vp.Contains(x, y) {
+			rows[y-vp.Y][x-vp.X] = gl
+		}
+	}
+	// corpses first, so that the living are drawn on top of them
+	for _, alive := range []bool{false, true} {
+		for _, m := range level.Monsters {
+			if m.IsAlive() == alive && visible.IsVisible(m.X, m.Y) {
+				put(m.X, m.Y, glyph{m.Glyph(), m.Colour(), grid.DEFAULT, false})
+			}
+		}
+	}
+	put(gm.Player.X, gm.Player.Y, glyph{gm.Player.Glyph(), gm.Player.Colour(), grid.DEFAULT, false})
+	return rows
+}
+
+// Return the glyph of the top item at (x,y) of items, or of the cell of g if there is none
+func cellGlyph(g *grid.Grid, items *item.Layer, x int, y int) glyph {
+	if top := items.Top(x, y); top != nil {
+		return glyph{top.Rune(), top.Colour(), grid.DEFAULT, false}
+	}
+	cell, err := g.Get(x, y)
+	if err != nil {
+		return blank
+	}
+	return glyph{cell.Type.Rune, cell.Type.Foreground, cell.Type.Background, false}
+}
+
+// Print rows of glyphs to v, emitting an escape only where the way glyphs are drawn changes
+func printGlyphs(v *gocui.View, rows [][]glyph) error {
+	var buffer bytes.Buffer
+	reset := glyphEscape(blank)
+	for _, row := range rows {
+		previous := reset
+		buffer.WriteString(reset)
+		for _, gl := range row {
+			if escape := glyphEscape(gl); escape != previous {
+				buffer.WriteString(escape)
+				previous = escape
+			}
+			buffer.WriteRune(gl.Rune)
+		}
+		buffer.WriteString(reset + "\n")
+	}
+	_, err := fmt.Fprint(v, buffer.String())
+	return err
+}
@@ -0,0 +1,28 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+func TestRememberedGlyphsStandOutInMonochrome(t *testing.T) {
+	defer func(monochrome bool) { Monochrome = monochrome }(Monochrome)
+	seen := glyph{'.', grid.WHITE, grid.DEFAULT, false}
+	remembered := glyph{'.', grid.WHITE, grid.DEFAULT, true}
+	for _, Monochrome = range []bool{false, true} {
+		if glyphEscape(seen) == glyphEscape(remembered) {
+			t.Errorf("monochrome %v: seen and remembered cells are drawn alike", Monochrome)
+		}
+	}
+}
+
+func TestDoorsHaveDistinctRunes(t *testing.T) {
+	runes := map[rune]bool{}
+	for _, door := range []grid.CellType{grid.DOOR_OPEN, grid.DOOR_CLOSED, grid.DOOR_LOCKED} {
+		if runes[door.Rune] {
+			t.Errorf("%s drawn with the rune of another door", door.Description)
+		}
+		runes[door.Rune] = true
+	}
+}
@@ -0,0 +1,72 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+)
+
+// Number of latest messages shown below the map
+const MESSAGE_LINES = 4
+
+// Colours of message severities
+var severityColours = map[game.Severity]grid.Colour{
+	game.INFO:    grid.DEFAULT,
+	game.GOOD:    grid.GREEN,
+	game.WARNING: grid.YELLOW,
+	game.DANGER:  grid.RED,
+}
+
+// Show the latest messages in a view of width starting from (x,y)
+func layoutMessages(gm *game.Game, gui *gocui.Gui, x int, y int, width int) error {
+	if messageView, err := gui.SetView("Messages", x, y, x+width, y+MESSAGE_LINES+1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		messageView.Title = "Messages"
+		messageView.Wrap = true
+		messageView.Autoscroll = true
+		printMessages(messageView, gm.Log.Last(MESSAGE_LINES))
+	}
+	return nil
+}
+
+func printMessages(v *gocui.View, messages []game.Message) {
+	for _, m := range messages {
+		fmt.Fprintf(v, "%s%s%s\n", colourEscape(severityColours[m.Severity], grid.DEFAULT), m.Text, colourEscape(grid.DEFAULT, grid.DEFAULT))
+	}
+}
+
+// Show the full message history over the map. Arrow keys scroll it, escape closes it.
+func MessageHistoryHandler(gm *game.Game) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		maxX, maxY := gcui.Size()
+		historyView, err := gcui.SetView("History", 1, 1, maxX-2, maxY-2)
+		if err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		historyView.Title = "Message history"
+		historyView.Clear()
+		printMessages(historyView, gm.Log.Messages)
+		_, height := historyView.Size()
+		top := len(gm.Log.Messages) - height
+		if top < 0 {
+			top = 0
+		}
+		historyView.SetOrigin(0, top)
+		return gcui.SetCurrentView("History")
+	}
+}
+
+// Scroll view by dy lines, bound to arrow keys of the "History" view
+func ScrollHandler(dy int) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		x, y := v.Origin()
+		if y+dy < 0 {
+			return nil
+		}
+		return v.SetOrigin(x, y+dy)
+	}
+}
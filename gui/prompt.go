@@ -0,0 +1,50 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+	"github.com/mahe-go/grogue/grid"
+)
+
+// Action waiting for the player to choose a direction with a movement key
+type directionPrompt struct {
+	Question string
+	Act      func(direction grid.Direction) error
+}
+
+// Prompt being answered, or nil when not asking for a direction
+var pending *directionPrompt
+
+// Ask question, then do act to the direction of the next movement key. Escape cancels.
+func DirectionPromptHandler(gm *game.Game, question string, act func(direction grid.Direction) error) gocui.KeybindingHandler {
+	return func(gcui *gocui.Gui, v *gocui.View) error {
+		pending = &directionPrompt{question, act}
+		Layout(gm, gcui)
+		return nil
+	}
+}
+
+// Answer the pending prompt with direction
+func answerPrompt(gm *game.Game, gcui *gocui.Gui, direction grid.Direction) error {
+	act := pending.Act
+	pending = nil
+	// the game tells the player why an action failed in the message log, it is no reason to stop the main loop
+	_ = act(direction)
+	Layout(gm, gcui)
+	return nil
+}
+
+// Show the pending question in a view of width starting from (x,y)
+func layoutPrompt(gui *gocui.Gui, x int, y int, width int) error {
+	if promptView, err := gui.SetView("Prompt", x, y, x+width, y+MESSAGE_LINES+1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		promptView.Title = "Which direction?"
+		fmt.Fprintln(promptView, pending.Question)
+		fmt.Fprint(promptView, "Press a movement key, or escape to cancel.")
+	}
+	return nil
+}
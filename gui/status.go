@@ -0,0 +1,37 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+	"github.com/mahe-go/grogue/game"
+)
+
+// Show a one line summary of the player's state in a view of width starting from (x,y)
+func layoutStatus(gm *game.Game, gui *gocui.Gui, x int, y int, width int) error {
+	if statusView, err := gui.SetView("Status", x, y, x+width, y+2); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		fmt.Fprint(statusView, statusLine(gm))
+	}
+	return nil
+}
+
+func statusLine(gm *game.Game) string {
+	p := gm.Player
+	parts := []string{
+		fmt.Sprintf("%s the %s", p.Name, p.Species.Name),
+		fmt.Sprintf("Depth: %d", gm.Dungeon.Depth),
+		fmt.Sprintf("HP: %d/%d", p.HitPoints, p.MaxHitPoints()),
+		fmt.Sprintf("Turn: %d", gm.Scheduler.Turn()),
+	}
+	if cell, err := gm.Level().Grid.Get(p.X, p.Y); err == nil {
+		parts = append(parts, "On: "+cell.Type.Description)
+	}
+	for _, e := range p.Effects {
+		parts = append(parts, e.Kind.String())
+	}
+	return strings.Join(parts, "  ")
+}
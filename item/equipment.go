@@ -0,0 +1,69 @@
+package item
+
+import "errors"
+
+var NOT_EQUIPPABLE = errors.New("Cannot be equipped")
+var SLOT_EMPTY = errors.New("Nothing equipped there")
+
+// Place on the body an item is wielded or worn in
+type Slot int
+
+const (
+	NO_SLOT Slot = iota
+	WEAPON_SLOT
+	BODY_SLOT
+	HEAD_SLOT
+	RING_SLOT
+)
+
+// All slots, in the order equipment is listed
+var Slots = []Slot{WEAPON_SLOT, BODY_SLOT, HEAD_SLOT, RING_SLOT}
+
+var slotNames = map[Slot]string{
+	NO_SLOT:     "none",
+	WEAPON_SLOT: "weapon",
+	BODY_SLOT:   "body",
+	HEAD_SLOT:   "head",
+	RING_SLOT:   "ring",
+}
+
+func (s Slot) String() string {
+	return slotNames[s]
+}
+
+// Items equipped in each slot
+type Equipment map[Slot]*Item
+
+// Put item to its slot. Returns the item previously in the slot, or nil.
+func (e Equipment) Equip(item *Item) (*Item, error) {
+	if !item.IsEquippable() {
+		return nil, NOT_EQUIPPABLE
+	}
+	previous := e[item.Slot]
+	e[item.Slot] = item
+	return previous, nil
+}
+
+// Remove and return the item in slot
+func (e Equipment) Unequip(slot Slot) (*Item, error) {
+	item, ok := e[slot]
+	if !ok || item == nil {
+		return nil, SLOT_EMPTY
+	}
+	delete(e, slot)
+	return item, nil
+}
+
+// Return the sum of bonuses of all equipped items
+func (e Equipment) Bonus() Bonus {
+	var total Bonus
+	for _, item := range e {
+		if item != nil {
+			total.Attack += item.Bonus.Attack
+			total.Defense += item.Bonus.Defense
+			total.Speed += item.Bonus.Speed
+			total.Sight += item.Bonus.Sight
+		}
+	}
+	return total
+}
@@ -0,0 +1,52 @@
+package item
+
+import "errors"
+
+var INVENTORY_FULL = errors.New("Inventory full")
+var NO_SUCH_ITEM = errors.New("No such item")
+
+// Items carried by a creature. Gold is kept in a single stack.
+type Inventory struct {
+	Items    []*Item
+	Capacity int
+}
+
+func NewInventory(capacity int) *Inventory {
+	return &Inventory{[]*Item{}, capacity}
+}
+
+// Add item to inventory
+func (inv *Inventory) Add(item *Item) error {
+	if item.Kind == GOLD {
+		for _, carried := range inv.Items {
+			if carried.Kind == GOLD {
+				carried.Amount += item.Amount
+				return nil
+			}
+		}
+	}
+	if len(inv.Items) >= inv.Capacity {
+		return INVENTORY_FULL
+	}
+	inv.Items = append(inv.Items, item)
+	return nil
+}
+
+// Remove and return item at index
+func (inv *Inventory) Remove(index int) (*Item, error) {
+	if index < 0 || index >= len(inv.Items) {
+		return nil, NO_SUCH_ITEM
+	}
+	item := inv.Items[index]
+	inv.Items = append(inv.Items[:index], inv.Items[index+1:]...)
+	return item, nil
+}
+
+// Return descriptions of carried items, in order
+func (inv *Inventory) List() []string {
+	list := make([]string, len(inv.Items))
+	for i, item := range inv.Items {
+		list[i] = item.Describe()
+	}
+	return list
+}
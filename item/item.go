@@ -0,0 +1,97 @@
+package item
+
+import (
+	"fmt"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+type Kind int
+
+const (
+	WEAPON Kind = iota
+	ARMOR
+	POTION
+	SCROLL
+	GOLD
+	RING
+)
+
+// Rune items of each kind are drawn with
+var kindRunes = map[Kind]rune{
+	WEAPON: ')',
+	ARMOR:  '[',
+	POTION: '!',
+	SCROLL: '?',
+	GOLD:   '$',
+	RING:   '=',
+}
+
+// Colour items of each kind are drawn with
+var kindColours = map[Kind]grid.Colour{
+	WEAPON: grid.CYAN,
+	ARMOR:  grid.CYAN,
+	POTION: grid.MAGENTA,
+	SCROLL: grid.WHITE,
+	GOLD:   grid.YELLOW,
+	RING:   grid.YELLOW,
+}
+
+// Modifiers an equipped item gives to the stats of its wearer.
+// Speed is energy per tick, of which a creature of movement 1 gains 10.
+type Bonus struct {
+	Attack  int
+	Defense int
+	Speed   int
+	Sight   int
+}
+
+type Item struct {
+	Kind   Kind
+	Name   string
+	Amount int
+	Slot   Slot
+	Bonus  Bonus
+}
+
+func NewItem(kind Kind, name string, amount int, slot Slot, bonus Bonus) *Item {
+	return &Item{kind, name, amount, slot, bonus}
+}
+
+// Return rune the item is drawn with
+func (i *Item) Rune() rune {
+	return kindRunes[i.Kind]
+}
+
+// Return colour the item is drawn with
+func (i *Item) Colour() grid.Colour {
+	return kindColours[i.Kind]
+}
+
+// Return description of the item for listings and messages
+func (i *Item) Describe() string {
+	if i.Kind == GOLD {
+		return fmt.Sprintf("%d gold pieces", i.Amount)
+	}
+	return i.Name
+}
+
+// Return true if the item can be wielded or worn
+func (i *Item) IsEquippable() bool {
+	return i.Slot != NO_SLOT
+}
+
+// Templates items are spawned from. Amount of gold is randomized on spawn.
+var Catalog = []Item{
+	{WEAPON, "dagger", 1, WEAPON_SLOT, Bonus{Attack: 2}},
+	{WEAPON, "short sword", 1, WEAPON_SLOT, Bonus{Attack: 3}},
+	{WEAPON, "mace", 1, WEAPON_SLOT, Bonus{Attack: 4, Speed: -1}},
+	{ARMOR, "leather armor", 1, BODY_SLOT, Bonus{Defense: 1}},
+	{ARMOR, "chain mail", 1, BODY_SLOT, Bonus{Defense: 3}},
+	{ARMOR, "leather cap", 1, HEAD_SLOT, Bonus{Defense: 1}},
+	{RING, "ring of haste", 1, RING_SLOT, Bonus{Speed: 5}},
+	{RING, "ring of far sight", 1, RING_SLOT, Bonus{Sight: 3}},
+	{POTION, "potion of healing", 1, NO_SLOT, Bonus{}},
+	{SCROLL, "scroll of light", 1, NO_SLOT, Bonus{}},
+	{GOLD, "gold", 1, NO_SLOT, Bonus{}},
+}
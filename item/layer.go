@@ -0,0 +1,130 @@
+package item
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/mahe-go/grogue/grid"
+)
+
+var NOTHING_HERE = errors.New("Nothing here")
+
+// Items lying on the cells of a level, keyed by location. Items on the same cell form a pile,
+// the most recently dropped item on top.
+type Layer struct {
+	piles map[grid.Point][]*Item
+}
+
+func NewLayer() *Layer {
+	return &Layer{make(map[grid.Point][]*Item)}
+}
+
+// Put item on top of the pile at (x,y)
+func (l *Layer) Put(x int, y int, item *Item) {
+	p := grid.Point{X: x, Y: y}
+	l.piles[p] = append(l.piles[p], item)
+}
+
+// Return items at (x,y), topmost last
+func (l *Layer) At(x int, y int) []*Item {
+	return l.piles[grid.Point{X: x, Y: y}]
+}
+
+// Return the topmost item at (x,y), or nil if there is none
+func (l *Layer) Top(x int, y int) *Item {
+	pile := l.At(x, y)
+	if len(pile) == 0 {
+		return nil
+	}
+	return pile[len(pile)-1]
+}
+
+// Remove and return the topmost item at (x,y)
+func (l *Layer) Take(x int, y int) (*Item, error) {
+	p := grid.Point{X: x, Y: y}
+	pile := l.piles[p]
+	if len(pile) == 0 {
+		return nil, NOTHING_HERE
+	}
+	item := pile[len(pile)-1]
+	if len(pile) == 1 {
+		delete(l.piles, p)
+	} else {
+		l.piles[p] = pile[:len(pile)-1]
+	}
+	return item, nil
+}
+
+// Replace the pile at (x,y) with items, topmost last
+func (l *Layer) Replace(x int, y int, items []*Item) {
+	p := grid.Point{X: x, Y: y}
+	if len(items) == 0 {
+		delete(l.piles, p)
+		return
+	}
+	l.piles[p] = append([]*Item(nil), items...)
+}
+
+// Return locations with items on them, row by row
+func (l *Layer) Locations() []grid.Point {
+	locations := make([]grid.Point, 0, len(l.piles))
+	for p := range l.piles {
+		locations = append(locations, p)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Y != locations[j].Y {
+			return locations[i].Y < locations[j].Y
+		}
+		return locations[i].X < locations[j].X
+	})
+	return locations
+}
+
+// Place count items picked randomly from catalog to cells of g matching where
+func SpawnItems(l *Layer, g *grid.Grid, catalog []Item, count int, where grid.LocationPredicate, rng *rand.Rand) {
+	var candidates []grid.Point
+	g.ApplyEverywhereMatching(func(g *grid.Grid, x int, y int) error {
+		candidates = append(candidates, grid.Point{X: x, Y: y})
+		return nil
+	}, where)
+
+	for i := 0; i < count && len(candidates) > 0 && len(catalog) > 0; i++ {
+		p := candidates[rng.Intn(len(candidates))]
+		item := catalog[rng.Intn(len(catalog))]
+		if item.Kind == GOLD {
+			item.Amount = 1 + rng.Intn(20)
+		}
+		l.Put(p.X, p.Y, &item)
+	}
+}
+
+// Serialized form of a pile
+type encodedPile struct {
+	X     int
+	Y     int
+	Items []*Item
+}
+
+func (l *Layer) MarshalJSON() ([]byte, error) {
+	piles := []encodedPile{}
+	for _, p := range l.Locations() {
+		piles = append(piles, encodedPile{p.X, p.Y, l.piles[p]})
+	}
+	return json.Marshal(piles)
+}
+
+func (l *Layer) UnmarshalJSON(data []byte) error {
+	var piles []encodedPile
+	if err := json.Unmarshal(data, &piles); err != nil {
+		return err
+	}
+	l.piles = make(map[grid.Point][]*Item)
+	for _, pile := range piles {
+		for _, item := range pile.Items {
+			l.Put(pile.X, pile.Y, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Return a seed based on current time
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Return seed as a string that can be shared and parsed back with ParseSeed
+func FormatSeed(seed int64) string {
+	return strconv.FormatInt(seed, 10)
+}
+
+// Return the seed a string stands for. Numbers are used as is, any other string is hashed,
+// so that a level can be shared as a word as well.
+func ParseSeed(s string) int64 {
+	if seed, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return seed
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(s))
+	return int64(hash.Sum64())
+}
+
+// Return a new random source seeded with seed
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// Random source counting the values drawn from it, so that its state can be saved as its seed
+// and the number of draws, and restored by drawing as many values again
+type Source struct {
+	seed   int64
+	draws  uint64
+	source rand.Source64
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{seed, 0, rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *Source) Int63() int64 {
+	s.draws++
+	return s.source.Int63()
+}
+
+func (s *Source) Uint64() uint64 {
+	s.draws++
+	return s.source.Uint64()
+}
+
+func (s *Source) Seed(seed int64) {
+	s.seed = seed
+	s.draws = 0
+	s.source.Seed(seed)
+}
+
+// Serialized form of a source
+type encodedSource struct {
+	Seed  int64
+	Draws uint64
+}
+
+func (s *Source) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encodedSource{s.seed, s.draws})
+}
+
+func (s *Source) UnmarshalJSON(data []byte) error {
+	var encoded encodedSource
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	*s = *NewSource(encoded.Seed)
+	for s.draws < encoded.Draws {
+		s.Uint64()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestSourceIsRestoredFromJSON(t *testing.T) {
+	source := NewSource(42)
+	rng := rand.New(source)
+	for i := 0; i < 100; i++ {
+		rng.Intn(1000)
+	}
+	data, err := json.Marshal(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := &Source{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	resumed := rand.New(restored)
+	for i := 0; i < 100; i++ {
+		if a, b := rng.Int63(), resumed.Int63(); a != b {
+			t.Fatalf("draw %d after restoring: %d, want %d", i, b, a)
+		}
+	}
+}
+
+func TestParseSeedIsStable(t *testing.T) {
+	if seed := ParseSeed("1234"); seed != 1234 {
+		t.Errorf("number parsed as %d", seed)
+	}
+	if ParseSeed("catacombs") != ParseSeed("catacombs") || ParseSeed("catacombs") == ParseSeed("crypt") {
+		t.Error("words must hash to stable, distinct seeds")
+	}
+}